@@ -35,6 +35,13 @@ const (
 	ProcessCommandKey = "processCommand"
 )
 
+// The ps arguments listing the user, the pid, the parent pid and the command line of all processes
+const (
+	DefaultPsArgs = "-eo user,pid,ppid,args"
+	// AlpinePsArgs is used on alpine, whose busybox ps lists all processes without -e
+	AlpinePsArgs = "-o user,pid,ppid,args"
+)
+
 func GetPidsByLocalPort(ctx context.Context, channel spec.Channel, localPort string) ([]string, error) {
 	available := channel.IsCommandAvailable(ctx, "ss")
 	if !available {
@@ -0,0 +1,269 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/log"
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+// readOnlyCommands are still executed in dry run mode, because the executors use them to resolve the targets.
+// The command is read-only only if its arguments pass the check, the nil check means any arguments are read-only.
+var readOnlyCommands = map[string]func(args []string) bool{
+	"ps": nil, "pgrep": nil, "pidof": nil, "which": nil, "type": nil,
+	"cat": nil, "grep": nil, "egrep": nil, "head": nil, "tail": nil, "tr": nil, "wc": nil,
+	"cut": nil, "ls": nil, "stat": nil, "test": nil, "netstat": nil, "lsof": nil,
+	"uname": nil, "id": nil, "whoami": nil, "df": nil, "du": nil, "free": nil, "nproc": nil,
+	"getconf": nil, "readlink": nil,
+	"command":  readOnlyCommandArgs,
+	"ss":       readOnlySsArgs,
+	"awk":      readOnlyAwkArgs,
+	"date":     readOnlyDateArgs,
+	"hostname": readOnlyHostnameArgs,
+	"find":     readOnlyFindArgs,
+	"sort":     readOnlySortArgs,
+}
+
+var commandSeparator = regexp.MustCompile(`\|\||&&|[|;&\n]`)
+
+// IsReadOnlyCommand returns true if every command in the pipeline does not change the system.
+// The output redirection is treated as a change, and the command not known as read-only is treated as a change too.
+func IsReadOnlyCommand(script, args string) bool {
+	line := strings.TrimSpace(script + " " + args)
+	if line == "" || strings.Contains(line, ">") || strings.Contains(line, "`") || strings.Contains(line, "$(") {
+		return false
+	}
+	for _, segment := range commandSeparator.Split(line, -1) {
+		fields := strings.Fields(segment)
+		if len(fields) == 0 {
+			continue
+		}
+		check, ok := readOnlyCommands[path.Base(fields[0])]
+		if !ok || (check != nil && !check(fields[1:])) {
+			return false
+		}
+	}
+	return true
+}
+
+// readOnlyCommandArgs allows command -v and command -V only, command runs the other arguments as the command
+func readOnlyCommandArgs(args []string) bool {
+	return len(args) > 0 && (args[0] == "-v" || args[0] == "-V")
+}
+
+// readOnlySsArgs rejects -K which kills the sockets
+func readOnlySsArgs(args []string) bool {
+	for _, arg := range args {
+		if arg == "--kill" || isShortOption(arg) && strings.Contains(arg, "K") {
+			return false
+		}
+	}
+	return true
+}
+
+// awkUnsafeProgram matches the awk program running commands or loading the extensions, for example @load "inplace"
+var awkUnsafeProgram = regexp.MustCompile(`\bsystem\s*\(|@`)
+
+// readOnlyAwkArgs allows the field separator and the variable options only, the other options may edit the files
+// in place or load the programs, for example -i inplace and -f. The output redirection and the pipes of the program
+// are rejected by IsReadOnlyCommand.
+func readOnlyAwkArgs(args []string) bool {
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+		if arg == "--" || !strings.HasPrefix(arg, "-") {
+			break
+		}
+		switch {
+		case arg == "-F" || arg == "-v":
+			idx++
+		case strings.HasPrefix(arg, "-F") || strings.HasPrefix(arg, "-v"):
+		default:
+			return false
+		}
+	}
+	return !awkUnsafeProgram.MatchString(strings.Join(args, " "))
+}
+
+// readOnlyDateArgs allows the output format and the utc options only, the others may set the time
+func readOnlyDateArgs(args []string) bool {
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "+") && !strings.HasPrefix(arg, "'+") && !strings.HasPrefix(arg, "\"+") &&
+			arg != "-u" && arg != "--utc" && arg != "-R" && arg != "-I" {
+			return false
+		}
+	}
+	return true
+}
+
+// readOnlyHostnameArgs allows the options printing the names and the addresses only, the others may set the hostname
+func readOnlyHostnameArgs(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "-a", "-A", "-d", "-f", "-i", "-I", "-s", "--alias", "--all-fqdns", "--domain", "--fqdn", "--long",
+			"--ip-address", "--all-ip-addresses", "--short":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// readOnlyFindArgs rejects the actions deleting, writing or executing
+func readOnlyFindArgs(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "-delete", "-exec", "-execdir", "-ok", "-okdir", "-fprint", "-fprint0", "-fprintf", "-fls":
+			return false
+		}
+	}
+	return true
+}
+
+// readOnlySortArgs rejects the output file and the compress program
+func readOnlySortArgs(args []string) bool {
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "--output") || strings.HasPrefix(arg, "--compress-program") ||
+			isShortOption(arg) && strings.Contains(arg, "o") {
+			return false
+		}
+	}
+	return true
+}
+
+func isShortOption(arg string) bool {
+	return len(arg) > 1 && arg[0] == '-' && arg[1] != '-'
+}
+
+// dryRunKey is the context key of the commands recorded by DryRunExec
+type dryRunKey struct{}
+
+type commandRecorder struct {
+	lock     sync.Mutex
+	commands []PlannedCommand
+}
+
+func (r *commandRecorder) record(command PlannedCommand) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.commands = append(r.commands, command)
+}
+
+func (r *commandRecorder) list() []PlannedCommand {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	commands := make([]PlannedCommand, len(r.commands))
+	copy(commands, r.commands)
+	return commands
+}
+
+// planCommand records the command to the recorder of DryRunExec if it exists, and returns the success response
+// without invoking the command if it changes the system in dry run mode. It returns nil if the command should be invoked.
+func planCommand(ctx context.Context, channelName, script, args string) *spec.Response {
+	command := newPlannedCommand(ctx, channelName, script, args)
+	if recorder, ok := ctx.Value(dryRunKey{}).(*commandRecorder); ok && recorder != nil {
+		recorder.record(command)
+	}
+	if command.Executed {
+		return nil
+	}
+	log.Infof(ctx, "dry run, skip the command in %s channel: %s %s", channelName, script, args)
+	return spec.ReturnSuccess("")
+}
+
+func newPlannedCommand(ctx context.Context, channelName, script, args string) PlannedCommand {
+	command := PlannedCommand{
+		Channel:  channelName,
+		Script:   script,
+		Args:     args,
+		Executed: !spec.IsDryRun(ctx) || IsReadOnlyCommand(script, args),
+	}
+	if options, ok := getNSExecOptions(ctx); ok {
+		command.Namespace = options
+	}
+	return command
+}
+
+// PlannedCommand is the command invoked by the executor in dry run mode
+type PlannedCommand struct {
+	Channel string `json:"channel"`
+	Script  string `json:"script"`
+	Args    string `json:"args,omitempty"`
+
+	// Namespace is the nsexec options, for example -t 1024 -n
+	Namespace string `json:"namespace,omitempty"`
+
+	// Executed is true for the read-only commands, which are executed to resolve the targets
+	Executed bool `json:"executed"`
+}
+
+// DryRunChannel records every Run invocation, and skips the commands which change the system in dry run mode
+type DryRunChannel struct {
+	spec.Channel
+	lock     sync.Mutex
+	commands []PlannedCommand
+}
+
+// NewDryRunChannel returns the recording channel wrapping the channel
+func NewDryRunChannel(channel spec.Channel) *DryRunChannel {
+	return &DryRunChannel{
+		Channel:  channel,
+		commands: make([]PlannedCommand, 0),
+	}
+}
+
+func (d *DryRunChannel) Run(ctx context.Context, script, args string) *spec.Response {
+	command := newPlannedCommand(ctx, d.Channel.Name(), script, args)
+	d.lock.Lock()
+	d.commands = append(d.commands, command)
+	d.lock.Unlock()
+	if response := planCommand(ctx, d.Channel.Name(), script, args); response != nil {
+		return response
+	}
+	// the command is recorded here, so the wrapped channel doesn't record it again
+	return d.Channel.Run(context.WithValue(ctx, dryRunKey{}, (*commandRecorder)(nil)), script, args)
+}
+
+// Commands returns the commands recorded in invocation order
+func (d *DryRunChannel) Commands() []PlannedCommand {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	commands := make([]PlannedCommand, len(d.commands))
+	copy(commands, d.commands)
+	return commands
+}
+
+// DryRunExec invokes the executor with the dry run flag, and returns the commands planned by the executor as the
+// response result. The executor is not modified, so it can be invoked concurrently. The commands are recorded by
+// LocalChannel, NSExecChannel and DryRunChannel, so the other channels should be wrapped by NewDryRunChannel.
+func DryRunExec(uid string, ctx context.Context, executor spec.Executor, model *spec.ExpModel) *spec.Response {
+	recorder := &commandRecorder{commands: make([]PlannedCommand, 0)}
+	ctx = context.WithValue(spec.SetDryRunFlag(ctx), dryRunKey{}, recorder)
+	response := executor.Exec(uid, ctx, model)
+	if response == nil {
+		response = spec.ResponseFailWithFlags(spec.ResultUnmarshalFailed, executor.Name(), "the executor returns nil response")
+	}
+	if !response.Success {
+		return spec.ResponseFail(response.Code, response.Err, recorder.list())
+	}
+	return spec.ReturnSuccess(recorder.list())
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+func TestIsReadOnlyCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		args   string
+		want   bool
+	}{
+		{name: "ps pipeline", script: "ps", args: `-eo user,pid,ppid,args | grep "java" | awk '{print $2}' | tr '\n' ' '`, want: true},
+		{name: "command available", script: "command", args: "-v tc", want: true},
+		{name: "absolute path", script: "/usr/bin/cat", args: "/etc/os-release", want: true},
+		{name: "tc", script: "tc", args: "qdisc add dev eth0 root netem delay 100ms"},
+		{name: "kill in pipeline", script: "pgrep", args: "java | xargs kill -9"},
+		{name: "redirection", script: "cat", args: "/etc/hosts > /tmp/hosts"},
+		{name: "command substitution", script: "cat", args: "$(rm -rf /tmp/x)"},
+		{name: "sequence", script: "ls", args: "/tmp; rm -rf /tmp/x"},
+		{name: "unknown command", script: "uniq", args: "/tmp/in /tmp/out"},
+		{name: "command -v", script: "command", args: "-v tc", want: true},
+		{name: "command runs", script: "command", args: "rm -rf /tmp/x"},
+		{name: "date format", script: "date", args: "+%s", want: true},
+		{name: "date set", script: "date", args: "-s 2020-01-01"},
+		{name: "hostname", script: "hostname", want: true},
+		{name: "hostname set", script: "hostname", args: "newname"},
+		{name: "find", script: "find", args: "/tmp -name x", want: true},
+		{name: "find delete", script: "find", args: "/ -delete"},
+		{name: "find exec", script: "find", args: "/tmp -exec rm {} ;"},
+		{name: "sort", script: "sort", args: "-n /tmp/x", want: true},
+		{name: "sort output", script: "sort", args: "-o /tmp/x /tmp/y"},
+		{name: "sort output cluster", script: "sort", args: "-uo /tmp/x /tmp/y"},
+		{name: "awk system", script: "awk", args: `'BEGIN{system("rm -rf /tmp/x")}'`},
+		{name: "awk program file", script: "awk", args: "-f /tmp/x.awk /tmp/y"},
+		{name: "awk in place", script: "awk", args: `-i inplace '{print "x"}' /etc/hosts`},
+		{name: "awk include", script: "awk", args: `--include x.awk '{print}' /etc/hosts`},
+		{name: "awk load", script: "awk", args: `'@load "inplace"; {print}' /etc/hosts`},
+		{name: "awk system with blank", script: "awk", args: `'BEGIN{system ("reboot")}'`},
+		{name: "awk output redirection", script: "awk", args: `'{print > "/etc/hosts"}' /tmp/x`},
+		{name: "awk pipe", script: "awk", args: `'{print | "sh"}' /tmp/x`},
+		{name: "awk field separator", script: "awk", args: `-F: -v OFS=, '{print $1}' /etc/passwd`, want: true},
+		{name: "ss kill", script: "ss", args: "-K dst 10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsReadOnlyCommand(tt.script, tt.args); got != tt.want {
+				t.Errorf("IsReadOnlyCommand() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeExecutor keeps the channel set and invokes exec, it returns success if exec is nil
+type fakeExecutor struct {
+	channel spec.Channel
+	exec    spec.ExecFunc
+}
+
+func (e *fakeExecutor) Name() string {
+	return "fake"
+}
+
+func (e *fakeExecutor) Exec(uid string, ctx context.Context, model *spec.ExpModel) *spec.Response {
+	if e.exec == nil {
+		return spec.ReturnSuccess(uid)
+	}
+	return e.exec(uid, ctx, model)
+}
+
+func (e *fakeExecutor) SetChannel(channel spec.Channel) {
+	e.channel = channel
+}
+
+func TestDryRunChannel(t *testing.T) {
+	mock := NewExpectChannel()
+	mock.ExpectRun("ps", Any()).Return(spec.ReturnSuccess("1 java"))
+	recorder := NewDryRunChannel(mock)
+
+	ctx := spec.SetDryRunFlag(context.Background())
+	if response := recorder.Run(ctx, "ps", "-ef"); response.Result != "1 java" {
+		t.Errorf("Run() = %v, want the result of the wrapped channel", response)
+	}
+	if response := recorder.Run(ctx, "kill", "-9 1"); !response.Success {
+		t.Errorf("Run() = %v, want the skipped command success", response)
+	}
+	commands := recorder.Commands()
+	if len(commands) != 2 || !commands[0].Executed || commands[1].Executed || commands[1].Channel != "mock" {
+		t.Errorf("Commands() = %+v, want ps executed and kill skipped", commands)
+	}
+	mock.Verify(t)
+}
+
+func TestDryRunExec(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "touched")
+	channel := NewLocalChannel()
+	executor := &fakeExecutor{channel: channel}
+	// the commands are run through the channel set by DryRunExec
+	executor.exec = func(uid string, ctx context.Context, model *spec.ExpModel) *spec.Response {
+		for _, command := range [][2]string{{"ls", os.TempDir()}, {"touch", file}} {
+			if response := executor.channel.Run(ctx, command[0], command[1]); !response.Success {
+				return response
+			}
+		}
+		return spec.ReturnSuccess(uid)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response := DryRunExec("e1", context.Background(), executor, &spec.ExpModel{Target: "file", ActionName: "add"})
+			commands, _ := response.Result.([]PlannedCommand)
+			if !response.Success || len(commands) != 2 || !commands[0].Executed || commands[1].Executed {
+				t.Errorf("DryRunExec() = %+v, want ls executed and touch skipped", response)
+			}
+		}()
+	}
+	wg.Wait()
+	if executor.channel != channel {
+		t.Errorf("DryRunExec() changes the executor channel")
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("DryRunExec() touched %s, err = %v", file, err)
+	}
+}
+
+func TestDryRunExecNilResponse(t *testing.T) {
+	executor := &fakeExecutor{exec: func(uid string, ctx context.Context, model *spec.ExpModel) *spec.Response {
+		return nil
+	}}
+	response := DryRunExec("e1", context.Background(), executor, &spec.ExpModel{Target: "file", ActionName: "add"})
+	if response == nil || response.Success || response.Code != spec.ResultUnmarshalFailed.Code {
+		t.Errorf("DryRunExec() = %v, want the failure of nil response", response)
+	}
+}
+
+func TestChannelDryRunSkip(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel spec.Channel
+		ctx     context.Context
+	}{
+		{name: "local", channel: NewLocalChannel(), ctx: context.Background()},
+		{name: "nsexec", channel: NewNSExecChannel(), ctx: context.WithValue(context.Background(), NSTargetFlagName, "1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "touched")
+			if response := tt.channel.Run(spec.SetDryRunFlag(tt.ctx), "touch", file); !response.Success {
+				t.Errorf("Run() = %v, want the skipped command success", response)
+			}
+			if _, err := os.Stat(file); !os.IsNotExist(err) {
+				t.Errorf("Run() touched %s in dry run mode, err = %v", file, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+	"github.com/chaosblade-io/chaosblade-spec-go/util"
+)
+
+// GuardedChannel recovers the panic in the wrapped channel. The methods returning *spec.Response
+// return the spec.PanicRecovered response, the methods returning error return the error of the response,
+// and GetPsArgs returns DefaultPsArgs.
+type GuardedChannel struct {
+	spec.Channel
+}
+
+// NewGuardedChannel returns the channel which never panics
+func NewGuardedChannel(channel spec.Channel) spec.Channel {
+	return &GuardedChannel{Channel: channel}
+}
+
+func (g *GuardedChannel) recover(ctx context.Context, method string, handle func(response *spec.Response)) {
+	if recovered := recover(); recovered != nil {
+		handle(spec.RecoverToResponse(ctx, g.Channel.Name()+"."+method, recovered))
+	}
+}
+
+func (g *GuardedChannel) Run(ctx context.Context, script, args string) *spec.Response {
+	return SafeRun(ctx, g.Channel, script, args)
+}
+
+// SafeRun invokes the channel, the panic in it is converted to the failure response
+func SafeRun(ctx context.Context, channel spec.Channel, script, args string) (response *spec.Response) {
+	if channel == nil || util.IsNil(channel) {
+		return spec.ResponseFailWithFlags(spec.ChannelNil)
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			response = spec.RecoverToResponse(ctx, channel.Name(), recovered)
+		}
+	}()
+	return channel.Run(ctx, script, args)
+}
+
+func (g *GuardedChannel) GetPidsByProcessCmdName(processName string, ctx context.Context) (pids []string, err error) {
+	defer g.recover(ctx, "GetPidsByProcessCmdName", func(response *spec.Response) {
+		pids, err = nil, errors.New(response.Err)
+	})
+	return g.Channel.GetPidsByProcessCmdName(processName, ctx)
+}
+
+func (g *GuardedChannel) GetPidsByProcessName(processName string, ctx context.Context) (pids []string, err error) {
+	defer g.recover(ctx, "GetPidsByProcessName", func(response *spec.Response) {
+		pids, err = nil, errors.New(response.Err)
+	})
+	return g.Channel.GetPidsByProcessName(processName, ctx)
+}
+
+func (g *GuardedChannel) GetPsArgs(ctx context.Context) (psArgs string) {
+	defer g.recover(ctx, "GetPsArgs", func(response *spec.Response) {
+		psArgs = DefaultPsArgs
+	})
+	return g.Channel.GetPsArgs(ctx)
+}
+
+func (g *GuardedChannel) IsAlpinePlatform(ctx context.Context) (alpine bool) {
+	defer g.recover(ctx, "IsAlpinePlatform", func(response *spec.Response) {
+		alpine = false
+	})
+	return g.Channel.IsAlpinePlatform(ctx)
+}
+
+func (g *GuardedChannel) IsAllCommandsAvailable(ctx context.Context, commandNames []string) (resp *spec.Response, ok bool) {
+	defer g.recover(ctx, "IsAllCommandsAvailable", func(response *spec.Response) {
+		resp, ok = response, false
+	})
+	return g.Channel.IsAllCommandsAvailable(ctx, commandNames)
+}
+
+func (g *GuardedChannel) IsCommandAvailable(ctx context.Context, commandName string) (available bool) {
+	defer g.recover(ctx, "IsCommandAvailable", func(response *spec.Response) {
+		available = false
+	})
+	return g.Channel.IsCommandAvailable(ctx, commandName)
+}
+
+func (g *GuardedChannel) ProcessExists(pid string) (exists bool, err error) {
+	defer g.recover(context.Background(), "ProcessExists", func(response *spec.Response) {
+		exists, err = false, errors.New(response.Err)
+	})
+	return g.Channel.ProcessExists(pid)
+}
+
+func (g *GuardedChannel) GetPidUser(pid string) (user string, err error) {
+	defer g.recover(context.Background(), "GetPidUser", func(response *spec.Response) {
+		user, err = "", errors.New(response.Err)
+	})
+	return g.Channel.GetPidUser(pid)
+}
+
+func (g *GuardedChannel) GetPidsByLocalPorts(ctx context.Context, localPorts []string) (pids []string, err error) {
+	defer g.recover(ctx, "GetPidsByLocalPorts", func(response *spec.Response) {
+		pids, err = nil, errors.New(response.Err)
+	})
+	return g.Channel.GetPidsByLocalPorts(ctx, localPorts)
+}
+
+func (g *GuardedChannel) GetPidsByLocalPort(ctx context.Context, localPort string) (pids []string, err error) {
+	defer g.recover(ctx, "GetPidsByLocalPort", func(response *spec.Response) {
+		pids, err = nil, errors.New(response.Err)
+	})
+	return g.Channel.GetPidsByLocalPort(ctx, localPort)
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+// panicChannel panics in every method except Name, because the embedded channel is nil
+type panicChannel struct {
+	spec.Channel
+}
+
+func (c *panicChannel) Name() string {
+	return "panic"
+}
+
+func TestGuardedChannel(t *testing.T) {
+	ctx := context.Background()
+	guarded := NewGuardedChannel(&panicChannel{})
+
+	if response := guarded.Run(ctx, "ls", ""); response.Code != spec.PanicRecovered.Code {
+		t.Errorf("Run() = %v, want code %d", response, spec.PanicRecovered.Code)
+	}
+	if response, ok := guarded.IsAllCommandsAvailable(ctx, []string{"tc"}); ok || response.Code != spec.PanicRecovered.Code {
+		t.Errorf("IsAllCommandsAvailable() = %v, %t, want the PanicRecovered response", response, ok)
+	}
+	if psArgs := guarded.GetPsArgs(ctx); psArgs != DefaultPsArgs {
+		t.Errorf("GetPsArgs() = %s, want %s", psArgs, DefaultPsArgs)
+	}
+	if guarded.IsAlpinePlatform(ctx) || guarded.IsCommandAvailable(ctx, "tc") {
+		t.Errorf("IsAlpinePlatform() or IsCommandAvailable() = true, want false")
+	}
+	errorMethods := map[string]func() error{
+		"GetPidsByProcessCmdName": func() error { _, err := guarded.GetPidsByProcessCmdName("java", ctx); return err },
+		"GetPidsByProcessName":    func() error { _, err := guarded.GetPidsByProcessName("java", ctx); return err },
+		"ProcessExists":           func() error { _, err := guarded.ProcessExists("1"); return err },
+		"GetPidUser":              func() error { _, err := guarded.GetPidUser("1"); return err },
+		"GetPidsByLocalPorts":     func() error { _, err := guarded.GetPidsByLocalPorts(ctx, []string{"80"}); return err },
+		"GetPidsByLocalPort":      func() error { _, err := guarded.GetPidsByLocalPort(ctx, "80"); return err },
+	}
+	for method, invoke := range errorMethods {
+		if err := invoke(); err == nil {
+			t.Errorf("%s() error = nil, want the recovered panic", method)
+		}
+	}
+
+	// the methods are delegated if the channel doesn't panic
+	mock := NewExpectChannel()
+	mock.ExpectGetPsArgs().Return(AlpinePsArgs)
+	if psArgs := NewGuardedChannel(mock).GetPsArgs(ctx); psArgs != AlpinePsArgs {
+		t.Errorf("GetPsArgs() = %s, want %s", psArgs, AlpinePsArgs)
+	}
+	mock.Verify(t)
+}
+
+func TestSafeRun(t *testing.T) {
+	if response := SafeRun(context.Background(), &panicChannel{}, "ls", ""); response.Code != spec.PanicRecovered.Code {
+		t.Errorf("SafeRun() = %v, want code %d", response, spec.PanicRecovered.Code)
+	}
+	if response := SafeRun(context.Background(), nil, "ls", ""); response.Code != spec.ChannelNil.Code {
+		t.Errorf("SafeRun() nil channel = %v, want code %d", response, spec.ChannelNil.Code)
+	}
+}
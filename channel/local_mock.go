@@ -31,6 +31,7 @@ type MockLocalChannel struct {
 	GetPidsByProcessCmdNameFunc func(processName string, ctx context.Context) ([]string, error)
 	GetPidsByProcessNameFunc    func(processName string, ctx context.Context) ([]string, error)
 	GetPsArgsFunc               func(ctx context.Context) string
+	IsAlpinePlatformFunc        func(ctx context.Context) bool
 	IsCommandAvailableFunc      func(ctx context.Context, commandName string) bool
 	ProcessExistsFunc           func(pid string) (bool, error)
 	GetPidUserFunc              func(pid string) (string, error)
@@ -45,6 +46,7 @@ func NewMockLocalChannel() spec.Channel {
 		GetPidsByProcessCmdNameFunc: defaultGetPidsByProcessCmdNameFunc,
 		GetPidsByProcessNameFunc:    defaultGetPidsByProcessNameFunc,
 		GetPsArgsFunc:               defaultGetPsArgsFunc,
+		IsAlpinePlatformFunc:        defaultIsAlpinePlatformFunc,
 		IsCommandAvailableFunc:      defaultIsCommandAvailableFunc,
 		ProcessExistsFunc:           defaultProcessExistsFunc,
 		GetPidUserFunc:              defaultGetPidUserFunc,
@@ -70,11 +72,11 @@ func (mlc *MockLocalChannel) GetPsArgs(ctx context.Context) string {
 }
 
 func (mlc *MockLocalChannel) IsAlpinePlatform(ctx context.Context) bool {
-	return false
+	return mlc.IsAlpinePlatformFunc(ctx)
 }
 
 func (mlc *MockLocalChannel) IsAllCommandsAvailable(ctx context.Context, commandNames []string) (*spec.Response, bool) {
-	return nil, false
+	return IsAllCommandsAvailable(ctx, mlc, commandNames)
 }
 
 func (mlc *MockLocalChannel) IsCommandAvailable(ctx context.Context, commandName string) bool {
@@ -114,7 +116,11 @@ var defaultGetPidsByProcessNameFunc = func(processName string, ctx context.Conte
 }
 
 var defaultGetPsArgsFunc = func(ctx context.Context) string {
-	return "-eo user,pid,ppid,args"
+	return DefaultPsArgs
+}
+
+var defaultIsAlpinePlatformFunc = func(ctx context.Context) bool {
+	return false
 }
 
 var defaultIsCommandAvailableFunc = func(ctx context.Context, commandName string) bool {
@@ -63,6 +63,9 @@ func (l *LocalChannel) Name() string {
 }
 
 func (l *LocalChannel) Run(ctx context.Context, script, args string) *spec.Response {
+	if response := planCommand(ctx, l.Name(), script, args); response != nil {
+		return response
+	}
 	return execScript(ctx, script, args)
 }
 
@@ -198,9 +201,9 @@ func getExcludeProcesses(ctx context.Context) []string {
 }
 
 func (l *LocalChannel) GetPsArgs(ctx context.Context) string {
-	psArgs := "-eo user,pid,ppid,args"
+	psArgs := DefaultPsArgs
 	if l.IsAlpinePlatform(ctx) {
-		psArgs = "-o user,pid,ppid,args"
+		psArgs = AlpinePsArgs
 	}
 	return psArgs
 }
@@ -0,0 +1,381 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+	"github.com/chaosblade-io/chaosblade-spec-go/util"
+)
+
+// Matcher matches the argument of the channel method
+type Matcher func(arg string) bool
+
+// Any matches any argument
+func Any() Matcher {
+	return func(arg string) bool {
+		return true
+	}
+}
+
+// Eq matches the argument equal to the value
+func Eq(value string) Matcher {
+	return func(arg string) bool {
+		return arg == value
+	}
+}
+
+// Contains matches the argument containing the substring
+func Contains(substr string) Matcher {
+	return func(arg string) bool {
+		return strings.Contains(arg, substr)
+	}
+}
+
+// MatchRegexp matches the argument by the regular expression
+func MatchRegexp(expr string) Matcher {
+	re := regexp.MustCompile(expr)
+	return func(arg string) bool {
+		return re.MatchString(arg)
+	}
+}
+
+// TestReporter is implemented by *testing.T
+type TestReporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+// MockCall is the invocation received by ExpectChannel, the slice argument is joined by comma
+type MockCall struct {
+	Method string
+	Args   []string
+}
+
+func (c MockCall) String() string {
+	return callKey(c.Method, c.Args...)
+}
+
+// Expectation is the expected invocation of ExpectChannel, it's expected once by default
+type Expectation struct {
+	method   string
+	desc     string
+	matchers []Matcher
+	min      int
+	max      int
+	calls    int
+
+	response *spec.Response
+	values   []string
+	value    string
+	boolean  bool
+	err      error
+
+	// unsupported records the types of the returned values not assignable, reported by Verify
+	unsupported []string
+}
+
+// Return sets the values returned by the method, the values are assigned by type:
+// *spec.Response, []string, string, bool and error, the other types fail the Verify
+func (e *Expectation) Return(values ...interface{}) *Expectation {
+	for _, value := range values {
+		switch v := value.(type) {
+		case *spec.Response:
+			e.response = v
+		case []string:
+			e.values = v
+		case string:
+			e.value = v
+		case bool:
+			e.boolean = v
+		case error:
+			e.err = v
+		default:
+			e.unsupported = append(e.unsupported, fmt.Sprintf("%T", value))
+		}
+	}
+	return e
+}
+
+// Times sets the exact invocation times
+func (e *Expectation) Times(times int) *Expectation {
+	e.min, e.max = times, times
+	return e
+}
+
+// AnyTimes allows the method to be invoked any times, including zero
+func (e *Expectation) AnyTimes() *Expectation {
+	e.min, e.max = 0, -1
+	return e
+}
+
+func (e *Expectation) matches(call MockCall) bool {
+	if e.method != call.Method || len(e.matchers) != len(call.Args) {
+		return false
+	}
+	for idx, matcher := range e.matchers {
+		if !matcher(call.Args[idx]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *Expectation) exhausted() bool {
+	return e.max >= 0 && e.calls >= e.max
+}
+
+func (e *Expectation) String() string {
+	return e.desc
+}
+
+// ExpectChannel is the spec.Channel mock driven by expectations, for example:
+//
+//	mock := NewExpectChannel()
+//	mock.ExpectRun("tc", Contains("delay")).Return(spec.ReturnSuccess("")).Times(1)
+//	... invoke the executor with the mock
+//	mock.Verify(t)
+type ExpectChannel struct {
+	ScriptPath string
+
+	lock         sync.Mutex
+	ordered      bool
+	expectations []*Expectation
+	calls        []MockCall
+	failures     []string
+}
+
+func NewExpectChannel() *ExpectChannel {
+	return &ExpectChannel{
+		ScriptPath:   util.GetBinPath(),
+		expectations: make([]*Expectation, 0),
+		calls:        make([]MockCall, 0),
+		failures:     make([]string, 0),
+	}
+}
+
+// InOrder requires the expectations to be satisfied in the declaration order
+func (m *ExpectChannel) InOrder() *ExpectChannel {
+	m.ordered = true
+	return m
+}
+
+func (m *ExpectChannel) expect(method string, matchers ...Matcher) *Expectation {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	expectation := &Expectation{method: method, desc: method, matchers: matchers, min: 1, max: 1}
+	m.expectations = append(m.expectations, expectation)
+	return expectation
+}
+
+func (m *ExpectChannel) ExpectRun(script string, args Matcher) *Expectation {
+	expectation := m.expect("Run", Eq(script), args)
+	expectation.desc = fmt.Sprintf("Run(%s, ...)", script)
+	return expectation
+}
+
+func (m *ExpectChannel) ExpectGetPidsByProcessCmdName(processName Matcher) *Expectation {
+	return m.expect("GetPidsByProcessCmdName", processName)
+}
+
+func (m *ExpectChannel) ExpectGetPidsByProcessName(processName Matcher) *Expectation {
+	return m.expect("GetPidsByProcessName", processName)
+}
+
+func (m *ExpectChannel) ExpectGetPsArgs() *Expectation {
+	return m.expect("GetPsArgs")
+}
+
+func (m *ExpectChannel) ExpectIsAlpinePlatform() *Expectation {
+	return m.expect("IsAlpinePlatform")
+}
+
+// ExpectIsAllCommandsAvailable matches the command names joined by comma, it returns nil,true by default
+func (m *ExpectChannel) ExpectIsAllCommandsAvailable(commandNames Matcher) *Expectation {
+	return m.expect("IsAllCommandsAvailable", commandNames).Return(true)
+}
+
+// ExpectIsCommandAvailable returns true by default
+func (m *ExpectChannel) ExpectIsCommandAvailable(commandName Matcher) *Expectation {
+	return m.expect("IsCommandAvailable", commandName).Return(true)
+}
+
+func (m *ExpectChannel) ExpectProcessExists(pid Matcher) *Expectation {
+	return m.expect("ProcessExists", pid)
+}
+
+func (m *ExpectChannel) ExpectGetPidUser(pid Matcher) *Expectation {
+	return m.expect("GetPidUser", pid)
+}
+
+// ExpectGetPidsByLocalPorts matches the ports joined by comma
+func (m *ExpectChannel) ExpectGetPidsByLocalPorts(localPorts Matcher) *Expectation {
+	return m.expect("GetPidsByLocalPorts", localPorts)
+}
+
+func (m *ExpectChannel) ExpectGetPidsByLocalPort(localPort Matcher) *Expectation {
+	return m.expect("GetPidsByLocalPort", localPort)
+}
+
+// Calls returns the invocation history
+func (m *ExpectChannel) Calls() []MockCall {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	calls := make([]MockCall, len(m.calls))
+	copy(calls, m.calls)
+	return calls
+}
+
+// Verify reports the unexpected calls, the calls out of order and the expectations not satisfied
+func (m *ExpectChannel) Verify(t TestReporter) {
+	t.Helper()
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, expectation := range m.expectations {
+		if len(expectation.unsupported) > 0 {
+			t.Fatalf("%s returns the unsupported value types %s", expectation, strings.Join(expectation.unsupported, ", "))
+		}
+	}
+	for _, failure := range m.failures {
+		t.Errorf("%s", failure)
+	}
+	for _, expectation := range m.expectations {
+		if expectation.calls < expectation.min {
+			t.Errorf("expected %s to be called %d times, but called %d times",
+				expectation, expectation.min, expectation.calls)
+		}
+	}
+}
+
+// invoke records the call and returns the matched expectation, returns nil if the call is unexpected
+func (m *ExpectChannel) invoke(method string, args ...string) *Expectation {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	call := MockCall{Method: method, Args: args}
+	m.calls = append(m.calls, call)
+	for idx, expectation := range m.expectations {
+		if expectation.exhausted() || !expectation.matches(call) {
+			continue
+		}
+		if m.ordered {
+			for _, previous := range m.expectations[:idx] {
+				if previous.calls < previous.min {
+					m.failures = append(m.failures, fmt.Sprintf("%s is called before the expected %s", call, previous))
+					break
+				}
+			}
+		}
+		expectation.calls++
+		return expectation
+	}
+	m.failures = append(m.failures, fmt.Sprintf("unexpected call: %s", call))
+	return nil
+}
+
+func (m *ExpectChannel) Name() string {
+	return "mock"
+}
+
+func (m *ExpectChannel) GetScriptPath() string {
+	return m.ScriptPath
+}
+
+func (m *ExpectChannel) Run(ctx context.Context, script, args string) *spec.Response {
+	expectation := m.invoke("Run", script, args)
+	if expectation == nil {
+		return spec.ResponseFailWithFlags(spec.CommandIllegal, fmt.Sprintf("unexpected call: %s %s", script, args))
+	}
+	if expectation.response == nil {
+		return spec.ReturnSuccess("")
+	}
+	return expectation.response
+}
+
+func (m *ExpectChannel) pids(method string, args ...string) ([]string, error) {
+	expectation := m.invoke(method, args...)
+	if expectation == nil {
+		return nil, fmt.Errorf("unexpected call: %s", callKey(method, args...))
+	}
+	if expectation.values == nil && expectation.err == nil {
+		return []string{}, nil
+	}
+	return expectation.values, expectation.err
+}
+
+func (m *ExpectChannel) GetPidsByProcessCmdName(processName string, ctx context.Context) ([]string, error) {
+	return m.pids("GetPidsByProcessCmdName", processName)
+}
+
+func (m *ExpectChannel) GetPidsByProcessName(processName string, ctx context.Context) ([]string, error) {
+	return m.pids("GetPidsByProcessName", processName)
+}
+
+func (m *ExpectChannel) GetPsArgs(ctx context.Context) string {
+	expectation := m.invoke("GetPsArgs")
+	if expectation == nil || expectation.value == "" {
+		return DefaultPsArgs
+	}
+	return expectation.value
+}
+
+func (m *ExpectChannel) IsAlpinePlatform(ctx context.Context) bool {
+	expectation := m.invoke("IsAlpinePlatform")
+	return expectation != nil && expectation.boolean
+}
+
+func (m *ExpectChannel) IsAllCommandsAvailable(ctx context.Context, commandNames []string) (*spec.Response, bool) {
+	expectation := m.invoke("IsAllCommandsAvailable", strings.Join(commandNames, ","))
+	if expectation == nil {
+		return spec.ResponseFailWithFlags(spec.CommandIllegal, "unexpected call: IsAllCommandsAvailable"), false
+	}
+	return expectation.response, expectation.boolean
+}
+
+func (m *ExpectChannel) IsCommandAvailable(ctx context.Context, commandName string) bool {
+	expectation := m.invoke("IsCommandAvailable", commandName)
+	return expectation != nil && expectation.boolean
+}
+
+func (m *ExpectChannel) ProcessExists(pid string) (bool, error) {
+	expectation := m.invoke("ProcessExists", pid)
+	if expectation == nil {
+		return false, fmt.Errorf("unexpected call: %s", callKey("ProcessExists", pid))
+	}
+	return expectation.boolean, expectation.err
+}
+
+func (m *ExpectChannel) GetPidUser(pid string) (string, error) {
+	expectation := m.invoke("GetPidUser", pid)
+	if expectation == nil {
+		return "", fmt.Errorf("unexpected call: %s", callKey("GetPidUser", pid))
+	}
+	return expectation.value, expectation.err
+}
+
+func (m *ExpectChannel) GetPidsByLocalPorts(ctx context.Context, localPorts []string) ([]string, error) {
+	return m.pids("GetPidsByLocalPorts", strings.Join(localPorts, ","))
+}
+
+func (m *ExpectChannel) GetPidsByLocalPort(ctx context.Context, localPort string) ([]string, error) {
+	return m.pids("GetPidsByLocalPort", localPort)
+}
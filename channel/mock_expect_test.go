@@ -0,0 +1,105 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+type errorRecorder struct {
+	errors []string
+	fatals []string
+}
+
+func (r *errorRecorder) Helper() {}
+
+func (r *errorRecorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *errorRecorder) Fatalf(format string, args ...interface{}) {
+	r.fatals = append(r.fatals, fmt.Sprintf(format, args...))
+}
+
+func TestExpectChannel(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		invoke     func(mock *ExpectChannel)
+		wantErrors int
+	}{
+		{
+			name: "satisfied",
+			invoke: func(mock *ExpectChannel) {
+				mock.IsCommandAvailable(ctx, "tc")
+				mock.Run(ctx, "tc", "qdisc add dev eth0 root netem delay 10ms")
+			},
+		},
+		{
+			name: "not called",
+			invoke: func(mock *ExpectChannel) {
+				mock.IsCommandAvailable(ctx, "tc")
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "out of order",
+			invoke: func(mock *ExpectChannel) {
+				mock.Run(ctx, "tc", "qdisc add dev eth0 root netem delay 10ms")
+				mock.IsCommandAvailable(ctx, "tc")
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "unexpected call",
+			invoke: func(mock *ExpectChannel) {
+				mock.IsCommandAvailable(ctx, "tc")
+				mock.Run(ctx, "tc", "qdisc add dev eth0 root netem delay 10ms")
+				mock.Run(ctx, "tc", "qdisc del dev eth0 root")
+			},
+			wantErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := NewExpectChannel().InOrder()
+			mock.ExpectIsCommandAvailable(Eq("tc"))
+			mock.ExpectRun("tc", Contains("delay")).Return(spec.ReturnSuccess("")).Times(1)
+			tt.invoke(mock)
+			recorder := &errorRecorder{}
+			mock.Verify(recorder)
+			if len(recorder.errors) != tt.wantErrors {
+				t.Errorf("Verify() errors = %v, want %d errors", recorder.errors, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestExpectationReturnUnsupported(t *testing.T) {
+	mock := NewExpectChannel()
+	mock.ExpectGetPidsByProcessName(Eq("nginx")).Return([]int{1, 2})
+	mock.GetPidsByProcessName("nginx", context.Background())
+	recorder := &errorRecorder{}
+	mock.Verify(recorder)
+	if len(recorder.fatals) != 1 {
+		t.Errorf("Verify() fatals = %v, want 1 fatal", recorder.fatals)
+	}
+}
@@ -52,23 +52,13 @@ func (l *NSExecChannel) Name() string {
 }
 
 func (l *NSExecChannel) Run(ctx context.Context, script, args string) *spec.Response {
-	pid := ctx.Value(NSTargetFlagName)
-	if pid == nil {
+	ns_script, ok := getNSExecOptions(ctx)
+	if !ok {
 		return spec.ResponseFailWithFlags(spec.CommandIllegal, script)
 	}
 
-	ns_script := fmt.Sprintf("-t %s", pid)
-
-	if ctx.Value(NSPidFlagName) == spec.True {
-		ns_script = fmt.Sprintf("%s -p", ns_script)
-	}
-
-	if ctx.Value(NSMntFlagName) == spec.True {
-		ns_script = fmt.Sprintf("%s -m", ns_script)
-	}
-
-	if ctx.Value(NSNetFlagName) == spec.True {
-		ns_script = fmt.Sprintf("%s -n", ns_script)
+	if response := planCommand(ctx, l.Name(), script, args); response != nil {
+		return response
 	}
 
 	isBladeCommand := isBladeCommand(script)
@@ -114,6 +104,30 @@ func (l *NSExecChannel) Run(ctx context.Context, script, args string) *spec.Resp
 	return spec.ResponseFailWithFlags(spec.OsCmdExecFailed, cmd, outMsg)
 }
 
+// getNSExecOptions returns the nsexec options by the namespace flags in the context,
+// returns false if the target pid is absent
+func getNSExecOptions(ctx context.Context) (string, bool) {
+	pid := ctx.Value(NSTargetFlagName)
+	if pid == nil {
+		return "", false
+	}
+
+	ns_script := fmt.Sprintf("-t %s", pid)
+
+	if ctx.Value(NSPidFlagName) == spec.True {
+		ns_script = fmt.Sprintf("%s -p", ns_script)
+	}
+
+	if ctx.Value(NSMntFlagName) == spec.True {
+		ns_script = fmt.Sprintf("%s -m", ns_script)
+	}
+
+	if ctx.Value(NSNetFlagName) == spec.True {
+		ns_script = fmt.Sprintf("%s -n", ns_script)
+	}
+	return ns_script, true
+}
+
 func (l *NSExecChannel) GetPidsByProcessCmdName(processName string, ctx context.Context) ([]string, error) {
 	excludeProcesses := ctx.Value(ExcludeProcessKey)
 	excludeGrepInfo := ""
@@ -202,9 +216,9 @@ func (l *NSExecChannel) IsCommandAvailable(ctx context.Context, commandName stri
 }
 
 func (l *NSExecChannel) GetPsArgs(ctx context.Context) string {
-	psArgs := "-eo user,pid,ppid,args"
+	psArgs := DefaultPsArgs
 	if l.IsAlpinePlatform(ctx) {
-		psArgs = "-o user,pid,ppid,args"
+		psArgs = AlpinePsArgs
 	}
 	return psArgs
 }
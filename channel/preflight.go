@@ -0,0 +1,198 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+	"github.com/chaosblade-io/chaosblade-spec-go/util"
+)
+
+// capabilityBits are the bit numbers of the linux capabilities in the CapEff mask of /proc/<pid>/status
+var capabilityBits = map[string]uint{
+	"CAP_CHOWN": 0, "CAP_DAC_OVERRIDE": 1, "CAP_DAC_READ_SEARCH": 2, "CAP_FOWNER": 3, "CAP_FSETID": 4,
+	"CAP_KILL": 5, "CAP_SETGID": 6, "CAP_SETUID": 7, "CAP_SETPCAP": 8, "CAP_LINUX_IMMUTABLE": 9,
+	"CAP_NET_BIND_SERVICE": 10, "CAP_NET_BROADCAST": 11, "CAP_NET_ADMIN": 12, "CAP_NET_RAW": 13,
+	"CAP_IPC_LOCK": 14, "CAP_IPC_OWNER": 15, "CAP_SYS_MODULE": 16, "CAP_SYS_RAWIO": 17, "CAP_SYS_CHROOT": 18,
+	"CAP_SYS_PTRACE": 19, "CAP_SYS_PACCT": 20, "CAP_SYS_ADMIN": 21, "CAP_SYS_BOOT": 22, "CAP_SYS_NICE": 23,
+	"CAP_SYS_RESOURCE": 24, "CAP_SYS_TIME": 25, "CAP_SYS_TTY_CONFIG": 26, "CAP_MKNOD": 27, "CAP_LEASE": 28,
+	"CAP_AUDIT_WRITE": 29, "CAP_AUDIT_CONTROL": 30, "CAP_SETFCAP": 31, "CAP_MAC_OVERRIDE": 32, "CAP_MAC_ADMIN": 33,
+	"CAP_SYSLOG": 34, "CAP_WAKE_ALARM": 35, "CAP_BLOCK_SUSPEND": 36, "CAP_AUDIT_READ": 37, "CAP_PERFMON": 38,
+	"CAP_BPF": 39, "CAP_CHECKPOINT_RESTORE": 40,
+}
+
+// archAliases maps the uname -m output to GOARCH
+var archAliases = map[string]string{
+	"x86_64": "amd64", "amd64": "amd64", "i386": "386", "i686": "386",
+	"aarch64": "arm64", "arm64": "arm64", "armv7l": "arm", "armv6l": "arm",
+	"ppc64le": "ppc64le", "s390x": "s390x", "riscv64": "riscv64", "loongarch64": "loong64",
+}
+
+const notRoot = "must be executed by root"
+
+// Preflight evaluates the requirements through the channel, so the requirements are checked in the
+// environment where the commands run, for example in the container entered by nsexec.
+// It returns nil,true if all requirements are met, otherwise all unmet requirements in one response
+// with RequirementsNotMet code, and the unmet requirements are the result. The Forbidden code is returned
+// instead if root is the only unmet requirement, which is the code of the executors checking root by themselves.
+func Preflight(ctx context.Context, channel spec.Channel, requirements *spec.ActionRequirements) (*spec.Response, bool) {
+	if requirements == nil {
+		return nil, true
+	}
+	unmet := make([]string, 0)
+	if len(requirements.OS) > 0 {
+		osName := preflightOS(ctx, channel)
+		if !containsFold(requirements.OS, osName) {
+			unmet = append(unmet, fmt.Sprintf("os must be one of %s, but it's %s", strings.Join(requirements.OS, ", "), osName))
+		}
+	}
+	if len(requirements.Arch) > 0 {
+		arch := preflightArch(ctx, channel)
+		if !containsFold(requirements.Arch, arch) {
+			unmet = append(unmet, fmt.Sprintf("arch must be one of %s, but it's %s", strings.Join(requirements.Arch, ", "), arch))
+		}
+	}
+	if requirements.Root {
+		response := channel.Run(ctx, "id", "-u")
+		if !response.Success || strings.TrimSpace(fmt.Sprint(response.Result)) != "0" {
+			unmet = append(unmet, notRoot)
+		}
+	}
+	if len(requirements.Capabilities) > 0 {
+		unmet = append(unmet, preflightCapabilities(ctx, channel, requirements.Capabilities)...)
+	}
+	for _, command := range requirements.Commands {
+		if !channel.IsCommandAvailable(ctx, command) {
+			unmet = append(unmet, fmt.Sprintf("`%s`: command not found", command))
+		}
+	}
+	if requirements.KernelVersion != "" {
+		response := channel.Run(ctx, "uname", "-r")
+		release := strings.TrimSpace(fmt.Sprint(response.Result))
+		if !response.Success {
+			unmet = append(unmet, fmt.Sprintf("kernel version must be at least %s, but it's unknown, %s",
+				requirements.KernelVersion, response.Err))
+		} else if util.CompareVersion(release, requirements.KernelVersion) < 0 {
+			unmet = append(unmet, fmt.Sprintf("kernel version must be at least %s, but it's %s",
+				requirements.KernelVersion, release))
+		}
+	}
+	if requirements.CgroupV2 {
+		response := channel.Run(ctx, "stat", "-fc %T "+spec.DefaultCGroupPath)
+		if !response.Success || strings.TrimSpace(fmt.Sprint(response.Result)) != "cgroup2fs" {
+			unmet = append(unmet, fmt.Sprintf("cgroup v2 must be mounted at %s", spec.DefaultCGroupPath))
+		}
+	}
+	if len(unmet) == 0 {
+		return nil, true
+	}
+	if len(unmet) == 1 && unmet[0] == notRoot {
+		return spec.ResponseFail(spec.Forbidden.Code, spec.Forbidden.Msg, unmet), false
+	}
+	return spec.ResponseFail(spec.RequirementsNotMet.Code,
+		fmt.Sprintf(spec.RequirementsNotMet.Msg, strings.Join(unmet, "; ")), unmet), false
+}
+
+// PreflightMiddleware checks the requirements of the action through the channel before creating the experiment.
+// The destroy command is not checked, so the experiment can always be cleaned up.
+func PreflightMiddleware(commandSpec spec.ExpModelCommandSpec, channel spec.Channel) spec.Middleware {
+	return func(next spec.Executor) spec.Executor {
+		return spec.WrapExecutor(next, func(uid string, ctx context.Context, model *spec.ExpModel) *spec.Response {
+			if _, ok := spec.IsDestroy(ctx); ok {
+				return next.Exec(uid, ctx, model)
+			}
+			action := spec.FindAction(commandSpec, model.ActionName)
+			if action == nil {
+				return spec.ResponseFailWithFlags(spec.ActionNotSupport, model.ActionName)
+			}
+			if response, ok := Preflight(ctx, channel, spec.RequirementsOf(action)); !ok {
+				return response
+			}
+			return next.Exec(uid, ctx, model)
+		})
+	}
+}
+
+// preflightOS returns the os name by uname -s, runtime.GOOS is returned if uname fails, for example on windows
+func preflightOS(ctx context.Context, channel spec.Channel) string {
+	response := channel.Run(ctx, "uname", "-s")
+	if !response.Success {
+		return runtime.GOOS
+	}
+	return strings.ToLower(strings.TrimSpace(fmt.Sprint(response.Result)))
+}
+
+// preflightArch returns the architecture in GOARCH form by uname -m, runtime.GOARCH is returned if uname fails
+func preflightArch(ctx context.Context, channel spec.Channel) string {
+	response := channel.Run(ctx, "uname", "-m")
+	if !response.Success {
+		return runtime.GOARCH
+	}
+	machine := strings.TrimSpace(fmt.Sprint(response.Result))
+	if arch, ok := archAliases[machine]; ok {
+		return arch
+	}
+	return machine
+}
+
+// preflightCapabilities returns the capabilities not in the effective capability set
+func preflightCapabilities(ctx context.Context, channel spec.Channel, capabilities []string) []string {
+	response := channel.Run(ctx, "grep", "CapEff /proc/self/status")
+	var effective uint64
+	var err error
+	if response.Success {
+		fields := strings.Fields(fmt.Sprint(response.Result))
+		if len(fields) == 2 {
+			effective, err = strconv.ParseUint(fields[1], 16, 64)
+		} else {
+			err = fmt.Errorf("unexpected output: %v", response.Result)
+		}
+	} else {
+		err = fmt.Errorf("%s", response.Err)
+	}
+	unmet := make([]string, 0)
+	for _, capability := range capabilities {
+		name := strings.ToUpper(capability)
+		if !strings.HasPrefix(name, "CAP_") {
+			name = "CAP_" + name
+		}
+		bit, ok := capabilityBits[name]
+		switch {
+		case !ok:
+			unmet = append(unmet, fmt.Sprintf("capability %s is unknown", capability))
+		case err != nil:
+			unmet = append(unmet, fmt.Sprintf("capability %s is required, but the capabilities are unknown, %v", name, err))
+		case effective&(1<<bit) == 0:
+			unmet = append(unmet, fmt.Sprintf("capability %s is required", name))
+		}
+	}
+	return unmet
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
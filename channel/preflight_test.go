@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+func TestPreflight(t *testing.T) {
+	ctx := context.Background()
+	requirements := &spec.ActionRequirements{
+		OS:            []string{"linux"},
+		Arch:          []string{"amd64", "arm64"},
+		Root:          true,
+		Capabilities:  []string{"CAP_NET_ADMIN", "sys_admin"},
+		Commands:      []string{"tc"},
+		KernelVersion: "4.9",
+		CgroupV2:      true,
+	}
+	tests := []struct {
+		name      string
+		uid       string
+		capEff    string
+		kernel    string
+		tc        bool
+		wantUnmet int
+		wantCode  int32
+	}{
+		{name: "met", uid: "0", capEff: "CapEff:\t000001ffffffffff", kernel: "5.10.0-136.el8.x86_64", tc: true},
+		{
+			name: "unmet", uid: "1000", capEff: "CapEff:\t0000000000001000", kernel: "3.10.0-1160.el7.x86_64",
+			wantUnmet: 4, wantCode: spec.RequirementsNotMet.Code,
+		},
+		{
+			name: "not root", uid: "1000", capEff: "CapEff:\t000001ffffffffff", kernel: "5.10.0-136.el8.x86_64", tc: true,
+			wantUnmet: 1, wantCode: spec.Forbidden.Code,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := NewExpectChannel()
+			mock.ExpectRun("uname", Eq("-s")).Return(spec.ReturnSuccess("Linux\n"))
+			mock.ExpectRun("uname", Eq("-m")).Return(spec.ReturnSuccess("aarch64\n"))
+			mock.ExpectRun("id", Eq("-u")).Return(spec.ReturnSuccess(tt.uid + "\n"))
+			mock.ExpectRun("grep", Contains("CapEff")).Return(spec.ReturnSuccess(tt.capEff + "\n"))
+			mock.ExpectIsCommandAvailable(Eq("tc")).Return(tt.tc)
+			mock.ExpectRun("uname", Eq("-r")).Return(spec.ReturnSuccess(tt.kernel + "\n"))
+			mock.ExpectRun("stat", Contains(spec.DefaultCGroupPath)).Return(spec.ReturnSuccess("cgroup2fs\n"))
+
+			response, ok := Preflight(ctx, mock, requirements)
+			if ok != (tt.wantUnmet == 0) {
+				t.Fatalf("Preflight() ok = %v, response: %v", ok, response)
+			}
+			if !ok {
+				if response.Code != tt.wantCode {
+					t.Errorf("Preflight() code = %d, want %d", response.Code, tt.wantCode)
+				}
+				if unmet := response.Result.([]string); len(unmet) != tt.wantUnmet {
+					t.Errorf("Preflight() unmet = %v, want %d requirements", unmet, tt.wantUnmet)
+				}
+			}
+			mock.Verify(t)
+		})
+	}
+}
+
+func TestPreflightMiddleware(t *testing.T) {
+	calls := 0
+	executor := &fakeExecutor{exec: func(uid string, ctx context.Context, model *spec.ExpModel) *spec.Response {
+		calls++
+		return spec.ReturnSuccess(uid)
+	}}
+	model := &spec.ExpCommandModel{
+		ExpName: "network",
+		ExpActions: []spec.ActionModel{{
+			ActionName:         "delay",
+			ActionRequirements: &spec.ActionRequirements{Commands: []string{"tc"}},
+		}},
+	}
+	mock := NewExpectChannel()
+	mock.ExpectIsCommandAvailable(Eq("tc")).Return(false)
+	wrapped := PreflightMiddleware(model, mock)(executor)
+
+	if response := wrapped.Exec("e1", context.Background(), &spec.ExpModel{Target: "network", ActionName: "delay"}); response.Success {
+		t.Errorf("Exec() = %v, want the unmet requirements", response)
+	}
+	destroyCtx := spec.SetDestroyFlag(context.Background(), "e1")
+	if response := wrapped.Exec("e1", destroyCtx, &spec.ExpModel{Target: "network", ActionName: "delay"}); !response.Success {
+		t.Errorf("Exec() destroy = %v, want success", response)
+	}
+	if calls != 1 {
+		t.Errorf("executor calls = %d, want 1", calls)
+	}
+	mock.Verify(t)
+}
@@ -0,0 +1,365 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/log"
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+// ChannelCall is the invocation of the spec.Channel method and the returned values
+type ChannelCall struct {
+	Method string   `json:"method"`
+	Args   []string `json:"args,omitempty"`
+
+	// Response is returned by Run and IsAllCommandsAvailable. The nil response is replayed as nil,
+	// and the Result is decoded from json, so it loses its type, for example a struct becomes
+	// map[string]interface{} and a number becomes float64.
+	Response *spec.Response `json:"response,omitempty"`
+	// Values is returned by the methods getting pids
+	Values []string `json:"values,omitempty"`
+	// Value is returned by GetPsArgs and GetPidUser
+	Value string `json:"value,omitempty"`
+	// Bool is returned by the methods returning bool
+	Bool bool `json:"bool,omitempty"`
+	// Err is the error message returned
+	Err string `json:"error,omitempty"`
+}
+
+func (c *ChannelCall) key() string {
+	return callKey(c.Method, c.Args...)
+}
+
+func (c *ChannelCall) error() error {
+	if c.Err == "" {
+		return nil
+	}
+	return errors.New(c.Err)
+}
+
+// callKey encodes the arguments as a json array, so the arguments containing the separators are not ambiguous
+func callKey(method string, args ...string) string {
+	if args == nil {
+		args = []string{}
+	}
+	bytes, _ := json.Marshal(args)
+	return method + string(bytes)
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// ChannelFixture is the file content saved by RecordChannel and loaded by ReplayChannel
+type ChannelFixture struct {
+	Name       string        `json:"name"`
+	ScriptPath string        `json:"scriptPath,omitempty"`
+	Calls      []ChannelCall `json:"calls"`
+}
+
+// RecordChannel invokes the wrapped channel and records every call, the calls can be saved as the fixture file
+type RecordChannel struct {
+	spec.Channel
+	lock  sync.Mutex
+	calls []ChannelCall
+}
+
+// NewRecordChannel returns the recording channel wrapping the real channel
+func NewRecordChannel(channel spec.Channel) *RecordChannel {
+	return &RecordChannel{
+		Channel: channel,
+		calls:   make([]ChannelCall, 0),
+	}
+}
+
+func (r *RecordChannel) record(call ChannelCall) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+// Calls returns the recorded calls in invocation order
+func (r *RecordChannel) Calls() []ChannelCall {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	calls := make([]ChannelCall, len(r.calls))
+	copy(calls, r.calls)
+	return calls
+}
+
+// Save writes the recorded calls to the fixture file in json format
+func (r *RecordChannel) Save(file string) error {
+	fixture := ChannelFixture{
+		Name:       r.Channel.Name(),
+		ScriptPath: r.Channel.GetScriptPath(),
+		Calls:      r.Calls(),
+	}
+	bytes, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, bytes, 0o644)
+}
+
+func (r *RecordChannel) Run(ctx context.Context, script, args string) *spec.Response {
+	response := r.Channel.Run(ctx, script, args)
+	r.record(ChannelCall{Method: "Run", Args: []string{script, args}, Response: response})
+	return response
+}
+
+func (r *RecordChannel) GetPidsByProcessCmdName(processName string, ctx context.Context) ([]string, error) {
+	pids, err := r.Channel.GetPidsByProcessCmdName(processName, ctx)
+	r.record(ChannelCall{Method: "GetPidsByProcessCmdName", Args: []string{processName}, Values: pids, Err: errorString(err)})
+	return pids, err
+}
+
+func (r *RecordChannel) GetPidsByProcessName(processName string, ctx context.Context) ([]string, error) {
+	pids, err := r.Channel.GetPidsByProcessName(processName, ctx)
+	r.record(ChannelCall{Method: "GetPidsByProcessName", Args: []string{processName}, Values: pids, Err: errorString(err)})
+	return pids, err
+}
+
+func (r *RecordChannel) GetPsArgs(ctx context.Context) string {
+	psArgs := r.Channel.GetPsArgs(ctx)
+	r.record(ChannelCall{Method: "GetPsArgs", Value: psArgs})
+	return psArgs
+}
+
+func (r *RecordChannel) IsAlpinePlatform(ctx context.Context) bool {
+	alpine := r.Channel.IsAlpinePlatform(ctx)
+	r.record(ChannelCall{Method: "IsAlpinePlatform", Bool: alpine})
+	return alpine
+}
+
+func (r *RecordChannel) IsAllCommandsAvailable(ctx context.Context, commandNames []string) (*spec.Response, bool) {
+	response, ok := r.Channel.IsAllCommandsAvailable(ctx, commandNames)
+	r.record(ChannelCall{Method: "IsAllCommandsAvailable", Args: commandNames, Response: response, Bool: ok})
+	return response, ok
+}
+
+func (r *RecordChannel) IsCommandAvailable(ctx context.Context, commandName string) bool {
+	available := r.Channel.IsCommandAvailable(ctx, commandName)
+	r.record(ChannelCall{Method: "IsCommandAvailable", Args: []string{commandName}, Bool: available})
+	return available
+}
+
+func (r *RecordChannel) ProcessExists(pid string) (bool, error) {
+	exists, err := r.Channel.ProcessExists(pid)
+	r.record(ChannelCall{Method: "ProcessExists", Args: []string{pid}, Bool: exists, Err: errorString(err)})
+	return exists, err
+}
+
+func (r *RecordChannel) GetPidUser(pid string) (string, error) {
+	user, err := r.Channel.GetPidUser(pid)
+	r.record(ChannelCall{Method: "GetPidUser", Args: []string{pid}, Value: user, Err: errorString(err)})
+	return user, err
+}
+
+func (r *RecordChannel) GetPidsByLocalPorts(ctx context.Context, localPorts []string) ([]string, error) {
+	pids, err := r.Channel.GetPidsByLocalPorts(ctx, localPorts)
+	r.record(ChannelCall{Method: "GetPidsByLocalPorts", Args: localPorts, Values: pids, Err: errorString(err)})
+	return pids, err
+}
+
+func (r *RecordChannel) GetPidsByLocalPort(ctx context.Context, localPort string) ([]string, error) {
+	pids, err := r.Channel.GetPidsByLocalPort(ctx, localPort)
+	r.record(ChannelCall{Method: "GetPidsByLocalPort", Args: []string{localPort}, Values: pids, Err: errorString(err)})
+	return pids, err
+}
+
+// ReplayChannel serves the recorded calls keyed by the method and the arguments.
+// The calls with the same key are served in the recorded order, and the last one is served repeatedly
+// when they are used up. The call not recorded is failed and kept in Unexpected, the methods which can't
+// return the error, for example GetPsArgs, return the zero values and log the miss.
+// The responses are served as they are saved, see ChannelCall.Response for the limits of json.
+type ReplayChannel struct {
+	name       string
+	scriptPath string
+
+	lock       sync.Mutex
+	fixtures   map[string][]ChannelCall
+	unexpected []string
+}
+
+// NewReplayChannel returns the replay channel serving the fixture
+func NewReplayChannel(fixture ChannelFixture) *ReplayChannel {
+	fixtures := make(map[string][]ChannelCall)
+	for _, call := range fixture.Calls {
+		fixtures[call.key()] = append(fixtures[call.key()], call)
+	}
+	return &ReplayChannel{
+		name:       fixture.Name,
+		scriptPath: fixture.ScriptPath,
+		fixtures:   fixtures,
+		unexpected: make([]string, 0),
+	}
+}
+
+// LoadReplayChannel returns the replay channel serving the fixture file saved by RecordChannel
+func LoadReplayChannel(file string) (*ReplayChannel, error) {
+	bytes, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var fixture ChannelFixture
+	if err := json.Unmarshal(bytes, &fixture); err != nil {
+		return nil, err
+	}
+	return NewReplayChannel(fixture), nil
+}
+
+// Unexpected returns the calls which are not recorded in the fixture
+func (r *ReplayChannel) Unexpected() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	unexpected := make([]string, len(r.unexpected))
+	copy(unexpected, r.unexpected)
+	return unexpected
+}
+
+// Err returns the error if there are unexpected calls
+func (r *ReplayChannel) Err() error {
+	unexpected := r.Unexpected()
+	if len(unexpected) == 0 {
+		return nil
+	}
+	return fmt.Errorf("unexpected channel calls: %s", strings.Join(unexpected, "; "))
+}
+
+func (r *ReplayChannel) replay(method string, args ...string) (ChannelCall, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	key := callKey(method, args...)
+	calls, ok := r.fixtures[key]
+	if !ok || len(calls) == 0 {
+		r.unexpected = append(r.unexpected, key)
+		return ChannelCall{}, fmt.Errorf("unexpected channel call: %s", key)
+	}
+	call := calls[0]
+	if len(calls) > 1 {
+		r.fixtures[key] = calls[1:]
+	}
+	return call, nil
+}
+
+// replayValue serves the call of the method which can't return the error, the zero values are returned
+// and the miss is logged if the call is not recorded
+func (r *ReplayChannel) replayValue(ctx context.Context, method string, args ...string) ChannelCall {
+	call, err := r.replay(method, args...)
+	if err != nil {
+		log.Warnf(ctx, "%v, the zero value is returned", err)
+	}
+	return call
+}
+
+func (r *ReplayChannel) Name() string {
+	return r.name
+}
+
+func (r *ReplayChannel) GetScriptPath() string {
+	return r.scriptPath
+}
+
+func (r *ReplayChannel) Run(ctx context.Context, script, args string) *spec.Response {
+	call, err := r.replay("Run", script, args)
+	if err != nil {
+		return spec.ResponseFailWithFlags(spec.CommandIllegal, err)
+	}
+	return call.Response
+}
+
+func (r *ReplayChannel) GetPidsByProcessCmdName(processName string, ctx context.Context) ([]string, error) {
+	call, err := r.replay("GetPidsByProcessCmdName", processName)
+	if err != nil {
+		return nil, err
+	}
+	return call.Values, call.error()
+}
+
+func (r *ReplayChannel) GetPidsByProcessName(processName string, ctx context.Context) ([]string, error) {
+	call, err := r.replay("GetPidsByProcessName", processName)
+	if err != nil {
+		return nil, err
+	}
+	return call.Values, call.error()
+}
+
+func (r *ReplayChannel) GetPsArgs(ctx context.Context) string {
+	call := r.replayValue(ctx, "GetPsArgs")
+	return call.Value
+}
+
+func (r *ReplayChannel) IsAlpinePlatform(ctx context.Context) bool {
+	call := r.replayValue(ctx, "IsAlpinePlatform")
+	return call.Bool
+}
+
+func (r *ReplayChannel) IsAllCommandsAvailable(ctx context.Context, commandNames []string) (*spec.Response, bool) {
+	call, err := r.replay("IsAllCommandsAvailable", commandNames...)
+	if err != nil {
+		return spec.ResponseFailWithFlags(spec.CommandIllegal, err), false
+	}
+	return call.Response, call.Bool
+}
+
+func (r *ReplayChannel) IsCommandAvailable(ctx context.Context, commandName string) bool {
+	call := r.replayValue(ctx, "IsCommandAvailable", commandName)
+	return call.Bool
+}
+
+func (r *ReplayChannel) ProcessExists(pid string) (bool, error) {
+	call, err := r.replay("ProcessExists", pid)
+	if err != nil {
+		return false, err
+	}
+	return call.Bool, call.error()
+}
+
+func (r *ReplayChannel) GetPidUser(pid string) (string, error) {
+	call, err := r.replay("GetPidUser", pid)
+	if err != nil {
+		return "", err
+	}
+	return call.Value, call.error()
+}
+
+func (r *ReplayChannel) GetPidsByLocalPorts(ctx context.Context, localPorts []string) ([]string, error) {
+	call, err := r.replay("GetPidsByLocalPorts", localPorts...)
+	if err != nil {
+		return nil, err
+	}
+	return call.Values, call.error()
+}
+
+func (r *ReplayChannel) GetPidsByLocalPort(ctx context.Context, localPort string) ([]string, error) {
+	call, err := r.replay("GetPidsByLocalPort", localPort)
+	if err != nil {
+		return nil, err
+	}
+	return call.Values, call.error()
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+func TestRecordAndReplayChannel(t *testing.T) {
+	mock := NewMockLocalChannel().(*MockLocalChannel)
+	mock.RunFunc = func(ctx context.Context, script, args string) *spec.Response {
+		return spec.ReturnSuccess(script + " " + args)
+	}
+	mock.GetPidsByProcessNameFunc = func(processName string, ctx context.Context) ([]string, error) {
+		return []string{"1024"}, nil
+	}
+	ctx := context.Background()
+	recorder := NewRecordChannel(mock)
+	recorder.Run(ctx, "tc", "qdisc show")
+	recorder.GetPidsByProcessName("java", ctx)
+
+	file := filepath.Join(t.TempDir(), "fixture.json")
+	if err := recorder.Save(file); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	replay, err := LoadReplayChannel(file)
+	if err != nil {
+		t.Fatalf("LoadReplayChannel() error = %v", err)
+	}
+	if replay.Name() != "mock" {
+		t.Errorf("Name() = %s, want mock", replay.Name())
+	}
+	if response := replay.Run(ctx, "tc", "qdisc show"); response.Result != "tc qdisc show" {
+		t.Errorf("Run() = %s, want the recorded response", response.Print())
+	}
+	if pids, _ := replay.GetPidsByProcessName("java", ctx); !reflect.DeepEqual(pids, []string{"1024"}) {
+		t.Errorf("GetPidsByProcessName() = %v, want [1024]", pids)
+	}
+	if replay.Err() != nil {
+		t.Errorf("Err() = %v, want nil", replay.Err())
+	}
+	if response := replay.Run(ctx, "tc", "qdisc del dev eth0 root"); response.Success {
+		t.Errorf("Run() unexpected call should fail")
+	}
+	if replay.Err() == nil {
+		t.Errorf("Err() should report the unexpected call")
+	}
+}
+
+func TestCallKey(t *testing.T) {
+	tests := []struct {
+		method string
+		args   []string
+		want   string
+	}{
+		{method: "GetPsArgs", want: `GetPsArgs[]`},
+		{method: "Run", args: []string{"echo", "a, b"}, want: `Run["echo","a, b"]`},
+		{method: "Run", args: []string{"echo, a", "b"}, want: `Run["echo, a","b"]`},
+	}
+	for _, tt := range tests {
+		if got := callKey(tt.method, tt.args...); got != tt.want {
+			t.Errorf("callKey(%s, %v) = %s, want %s", tt.method, tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestReplayChannelNilResponse(t *testing.T) {
+	replay := NewReplayChannel(ChannelFixture{Calls: []ChannelCall{{Method: "Run", Args: []string{"id", "-u"}}}})
+	if response := replay.Run(context.Background(), "id", "-u"); response != nil {
+		t.Errorf("Run() = %v, want the recorded nil response", response)
+	}
+}
+
+func TestReplayChannelZeroValues(t *testing.T) {
+	replay := NewReplayChannel(ChannelFixture{})
+	if psArgs := replay.GetPsArgs(context.Background()); psArgs != "" {
+		t.Errorf("GetPsArgs() = %s, want the zero value", psArgs)
+	}
+	if replay.IsCommandAvailable(context.Background(), "tc") {
+		t.Errorf("IsCommandAvailable() = true, want the zero value")
+	}
+	if unexpected := replay.Unexpected(); len(unexpected) != 2 {
+		t.Errorf("Unexpected() = %v, want the two calls", unexpected)
+	}
+}
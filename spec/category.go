@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Category is the scenario category of the actions, the name is in the form of domain/kind, for example system/cpu
+type Category struct {
+	Name string `json:"name" yaml:"name"`
+	Desc string `json:"desc" yaml:"desc"`
+}
+
+var (
+	categoryLock sync.RWMutex
+	categories   = map[string]Category{}
+)
+
+func init() {
+	for _, category := range []Category{
+		{"system/cpu", "CPU load and usage"},
+		{"system/mem", "memory load and usage"},
+		{"system/disk", "disk capacity and IO"},
+		{"system/network", "network interface and traffic"},
+		{"system/process", "process kill and stop"},
+		{"system/file", "file content and permission"},
+		{"system/script", "shell script execution"},
+		{"system/time", "system time travel"},
+		{"system/kernel", "kernel functions and system calls"},
+		{"system/systemd", "systemd services"},
+		{"network/latency", "network latency"},
+		{"network/loss", "network packet loss"},
+		{"network/dns", "domain name resolution"},
+		{"network/corruption", "network packet corruption"},
+		{"network/duplication", "network packet duplication"},
+		{"network/reorder", "network packet reorder"},
+		{"network/occupy", "network port occupation"},
+		{"application/java", "java applications"},
+		{"application/cplus", "c++ applications"},
+		{"application/golang", "golang applications"},
+		{"container/docker", "docker containers"},
+		{"container/cri", "containers managed by the container runtime interface"},
+		{"kubernetes/node", "kubernetes nodes"},
+		{"kubernetes/pod", "kubernetes pods"},
+		{"kubernetes/container", "kubernetes containers"},
+		{"cloud/aliyun", "alibaba cloud resources"},
+	} {
+		categories[category.Name] = category
+	}
+}
+
+// NormalizeCategory returns the category name in the form of domain/kind.
+// The legacy form joined by underscore is supported, for example system_cpu is normalized to system/cpu.
+func NormalizeCategory(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if strings.Contains(name, "/") {
+		return name
+	}
+	return strings.Replace(name, "_", "/", 1)
+}
+
+// RegisterCategory adds the category to the taxonomy, the description is replaced if it exists.
+// The executor projects register their own categories before checking or validating the specs,
+// otherwise the categories are reported as unknown.
+func RegisterCategory(name, desc string) {
+	categoryLock.Lock()
+	defer categoryLock.Unlock()
+	name = NormalizeCategory(name)
+	categories[name] = Category{Name: name, Desc: desc}
+}
+
+// UnregisterCategory removes the category from the taxonomy
+func UnregisterCategory(name string) {
+	categoryLock.Lock()
+	defer categoryLock.Unlock()
+	delete(categories, NormalizeCategory(name))
+}
+
+// GetCategory returns the registered category by the name in either form
+func GetCategory(name string) (Category, bool) {
+	categoryLock.RLock()
+	defer categoryLock.RUnlock()
+	category, ok := categories[NormalizeCategory(name)]
+	return category, ok
+}
+
+// Categories returns all registered categories sorted by name
+func Categories() []Category {
+	categoryLock.RLock()
+	defer categoryLock.RUnlock()
+	result := make([]Category, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, category)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// CheckCategories returns the errors of the action categories which are not registered,
+// the actions of the sub models are checked too if the command spec is the ExpCommandModel
+func CheckCategories(commandSpec ExpModelCommandSpec) []error {
+	return checkCategories(commandSpec.Name(), commandSpec)
+}
+
+func checkCategories(path string, commandSpec ExpModelCommandSpec) []error {
+	errs := make([]error, 0)
+	for _, action := range commandSpec.Actions() {
+		for _, name := range action.Categories() {
+			if _, ok := GetCategory(name); !ok {
+				errs = append(errs, fmt.Errorf("%s %s: unknown category %s", path, action.Name(), name))
+			}
+		}
+	}
+	if model, ok := commandSpec.(*ExpCommandModel); ok {
+		for idx := range model.ExpSubModels {
+			child := &model.ExpSubModels[idx]
+			errs = append(errs, checkCategories(path+" "+child.Name(), child)...)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"testing"
+)
+
+func TestCheckCategories(t *testing.T) {
+	RegisterCategory("middleware_redis", "redis servers")
+	t.Cleanup(func() {
+		UnregisterCategory("middleware_redis")
+	})
+	model := &ExpCommandModel{
+		ExpName: "network",
+		ExpActions: []ActionModel{
+			{ActionName: "delay", ActionCategories: []string{"system_network", "network/latency"}},
+			{ActionName: "flush", ActionCategories: []string{"middleware/redis", "network_unknown"}},
+		},
+		ExpSubModels: []ExpCommandModel{
+			{
+				ExpName:    "dns",
+				ExpActions: []ActionModel{{ActionName: "fail", ActionCategories: []string{"network_unknown"}}},
+			},
+		},
+	}
+	errs := CheckCategories(model)
+	want := []string{"network flush: unknown category network_unknown", "network dns fail: unknown category network_unknown"}
+	if len(errs) != len(want) {
+		t.Fatalf("CheckCategories() = %v, want %v", errs, want)
+	}
+	for idx, err := range errs {
+		if err.Error() != want[idx] {
+			t.Errorf("CheckCategories()[%d] = %v, want %s", idx, err, want[idx])
+		}
+	}
+	if category, ok := GetCategory(" System_CPU "); !ok || category.Name != "system/cpu" {
+		t.Errorf("GetCategory() = %v, %t, want system/cpu", category, ok)
+	}
+}
+
+func TestUnregisterCategory(t *testing.T) {
+	RegisterCategory("middleware/kafka", "kafka brokers")
+	UnregisterCategory("middleware_kafka")
+	if _, ok := GetCategory("middleware/kafka"); ok {
+		t.Errorf("GetCategory() after UnregisterCategory() = true, want false")
+	}
+}
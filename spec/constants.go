@@ -31,4 +31,9 @@ const (
 	DefaultCGroupPath  = "/sys/fs/cgroup/"
 	Uid                = "uid"
 	YamlPathEnv        = "YAML_PATH"
+	TimeoutFlag        = "timeout"
+	AsyncFlag          = "async"
+	EndpointFlag       = "endpoint"
+	DebugFlag          = "debug"
+	DryRunFlag         = "dry-run"
 )
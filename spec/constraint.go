@@ -0,0 +1,155 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FlagConstraintType is the rule type between the action flags
+type FlagConstraintType string
+
+const (
+	// OneOf requires exactly one of the flags
+	OneOf FlagConstraintType = "oneOf"
+	// AllOrNone requires all flags if any of them is present
+	AllOrNone FlagConstraintType = "allOrNone"
+	// Requires requires all flags if the trigger flag is present
+	Requires FlagConstraintType = "requires"
+	// ConflictsWith rejects the flags if the trigger flag is present
+	ConflictsWith FlagConstraintType = "conflicts"
+)
+
+// FlagConstraint is the rule between the action flags, for example:
+//
+//	constraints:
+//	- type: oneOf
+//	  flags: [pid, process]
+//	- type: requires
+//	  flag: destination-ip
+//	  flags: [interface]
+type FlagConstraint struct {
+	Type FlagConstraintType `yaml:"type" json:"type"`
+	// Flag is the trigger flag of Requires and ConflictsWith
+	Flag  string   `yaml:"flag,omitempty" json:"flag,omitempty"`
+	Flags []string `yaml:"flags,flow" json:"flags"`
+}
+
+func (c FlagConstraint) String() string {
+	switch c.Type {
+	case OneOf:
+		return fmt.Sprintf("exactly one of %s is required", strings.Join(c.Flags, ", "))
+	case AllOrNone:
+		return fmt.Sprintf("%s must be all present or all absent", strings.Join(c.Flags, ", "))
+	case Requires:
+		return fmt.Sprintf("%s requires %s", c.Flag, strings.Join(c.Flags, ", "))
+	case ConflictsWith:
+		return fmt.Sprintf("%s conflicts with %s", c.Flag, strings.Join(c.Flags, ", "))
+	}
+	return fmt.Sprintf("unknown constraint type %s", c.Type)
+}
+
+// ExpActionConstraintSpec is implemented by the actions declaring the flag constraints
+type ExpActionConstraintSpec interface {
+	// Constraints returns the flag constraints of the action
+	Constraints() []FlagConstraint
+}
+
+// ActionConstraints returns the flag constraints of the action, returns nil if the action doesn't implement ExpActionConstraintSpec
+func ActionConstraints(action ExpActionCommandSpec) []FlagConstraint {
+	if constraintSpec, ok := action.(ExpActionConstraintSpec); ok {
+		return constraintSpec.Constraints()
+	}
+	return nil
+}
+
+// Check returns the flags violating the constraint, returns nil if the flags satisfy it.
+// The flag with empty value is treated as absent.
+func (c FlagConstraint) Check(flags map[string]string) []string {
+	present := make([]string, 0)
+	for _, name := range c.Flags {
+		if flags[name] != "" {
+			present = append(present, name)
+		}
+	}
+	switch c.Type {
+	case OneOf:
+		if len(present) == 1 {
+			return nil
+		}
+		return c.Flags
+	case AllOrNone:
+		if len(present) == 0 || len(present) == len(c.Flags) {
+			return nil
+		}
+		return c.Flags
+	case Requires:
+		if flags[c.Flag] == "" || len(present) == len(c.Flags) {
+			return nil
+		}
+		return append([]string{c.Flag}, c.Flags...)
+	case ConflictsWith:
+		if flags[c.Flag] == "" || len(present) == 0 {
+			return nil
+		}
+		return append([]string{c.Flag}, present...)
+	}
+	return c.Flags
+}
+
+// ValidateFlagConstraints returns nil,true if the flags satisfy all constraints,
+// otherwise returns the ParameterConstraintViolated response of the first violated constraint
+func ValidateFlagConstraints(constraints []FlagConstraint, flags map[string]string) (*Response, bool) {
+	for _, constraint := range constraints {
+		if violated := constraint.Check(flags); violated != nil {
+			return ResponseFailWithFlags(ParameterConstraintViolated, strings.Join(violated, "|"), constraint), false
+		}
+	}
+	return nil, true
+}
+
+// CheckFlagConstraints returns the errors of the constraints which refer to the flags not declared by the action
+func CheckFlagConstraints(commandSpec ExpModelCommandSpec, action ExpActionCommandSpec) []error {
+	flags, _ := EffectiveFlags(commandSpec, action)
+	declared := make(map[string]bool)
+	for _, flag := range flags {
+		declared[flag.FlagName()] = true
+	}
+	errs := make([]error, 0)
+	for _, constraint := range ActionConstraints(action) {
+		switch constraint.Type {
+		case OneOf, AllOrNone:
+			if len(constraint.Flags) < 2 {
+				errs = append(errs, fmt.Errorf("%s %s: the %s constraint needs two flags at least", commandSpec.Name(), action.Name(), constraint.Type))
+			}
+		case Requires, ConflictsWith:
+			if constraint.Flag == "" || len(constraint.Flags) == 0 {
+				errs = append(errs, fmt.Errorf("%s %s: the %s constraint needs the flag and the flags", commandSpec.Name(), action.Name(), constraint.Type))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("%s %s: unknown constraint type %s", commandSpec.Name(), action.Name(), constraint.Type))
+			continue
+		}
+		for _, name := range append([]string{constraint.Flag}, constraint.Flags...) {
+			if name != "" && !declared[name] {
+				errs = append(errs, fmt.Errorf("%s %s: the %s flag of the %s constraint is not declared", commandSpec.Name(), action.Name(), name, constraint.Type))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"context"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+const constraintAction = `
+action: kill
+flags:
+- name: pid
+- name: process
+- name: signal
+- name: destination-ip
+- name: interface
+- name: local-port
+- name: remote-port
+constraints:
+- type: oneOf
+  flags: [pid, process]
+- type: requires
+  flag: destination-ip
+  flags: [interface]
+- type: conflicts
+  flag: local-port
+  flags: [remote-port]
+`
+
+func TestValidateFlagConstraints(t *testing.T) {
+	action := ActionModel{}
+	if err := yaml.Unmarshal([]byte(constraintAction), &action); err != nil {
+		t.Fatalf("unmarshal action error = %v", err)
+	}
+	model := &ExpCommandModel{ExpName: "process", ExpActions: []ActionModel{action}}
+	if errs := CheckFlagConstraints(model, model.Actions()[0]); len(errs) != 0 {
+		t.Errorf("CheckFlagConstraints() = %v, want no errors", errs)
+	}
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		success bool
+	}{
+		{name: "one of", flags: map[string]string{"pid": "1"}, success: true},
+		{name: "none of one of", flags: map[string]string{"signal": "9"}},
+		{name: "both of one of", flags: map[string]string{"pid": "1", "process": "java"}},
+		{name: "requires", flags: map[string]string{"pid": "1", "destination-ip": "10.0.0.1", "interface": "eth0"}, success: true},
+		{name: "requires missing", flags: map[string]string{"pid": "1", "destination-ip": "10.0.0.1"}},
+		{name: "conflicts", flags: map[string]string{"pid": "1", "local-port": "80", "remote-port": "8080"}},
+		{name: "conflicts absent", flags: map[string]string{"pid": "1", "remote-port": "8080"}, success: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, ok := ValidateExpModel(context.Background(), model, &ExpModel{ActionName: "kill", ActionFlags: tt.flags})
+			if ok != tt.success {
+				t.Errorf("ValidateExpModel() = %v, want success %t", response, tt.success)
+			}
+			if !ok && response.Code != ParameterConstraintViolated.Code {
+				t.Errorf("ValidateExpModel() code = %d, want %d", response.Code, ParameterConstraintViolated.Code)
+			}
+		})
+	}
+}
+
+func TestCheckFlagConstraints(t *testing.T) {
+	model := &ExpCommandModel{
+		ExpName: "process",
+		ExpActions: []ActionModel{{
+			ActionName:  "kill",
+			ActionFlags: []ExpFlag{{Name: "pid"}},
+			ActionConstraints: []FlagConstraint{
+				{Type: OneOf, Flags: []string{"pid", "process"}},
+				{Type: Requires, Flags: []string{"pid"}},
+				{Type: "xor", Flags: []string{"pid"}},
+			},
+		}},
+	}
+	if errs := CheckFlagConstraints(model, model.Actions()[0]); len(errs) != 3 {
+		t.Errorf("CheckFlagConstraints() = %v, want 3 errors", errs)
+	}
+}
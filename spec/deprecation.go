@@ -0,0 +1,144 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Deprecation is the deprecation metadata of the target, the action or the flag
+type Deprecation struct {
+	// Since is the version deprecating it
+	Since string `yaml:"since,omitempty" json:"since,omitempty"`
+	// Replacement is the name of the target, the action or the flag to use instead.
+	// The deprecated action and flag are replaced by DeprecationMiddleware automatically.
+	Replacement string `yaml:"replacement,omitempty" json:"replacement,omitempty"`
+	// RemovedIn is the version removing it
+	RemovedIn string `yaml:"removedIn,omitempty" json:"removedIn,omitempty"`
+	// Message is the additional description
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// Warning returns the warning message of the deprecated item, for example:
+// the `--pid` flag is deprecated since v1.7.0 and will be removed in v2.0.0, use `--process-id` instead.
+// The flag name and its replacement are prefixed with -- whether they have the prefix or not.
+func (d *Deprecation) Warning(kind, name string) string {
+	if kind == "flag" {
+		name = "--" + strings.TrimPrefix(name, "--")
+	}
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "the `%s` %s is deprecated", name, kind)
+	if d.Since != "" {
+		fmt.Fprintf(&builder, " since %s", d.Since)
+	}
+	if d.RemovedIn != "" {
+		fmt.Fprintf(&builder, " and will be removed in %s", d.RemovedIn)
+	}
+	if d.Replacement != "" {
+		replacement := d.Replacement
+		if kind == "flag" {
+			replacement = "--" + strings.TrimPrefix(replacement, "--")
+		}
+		fmt.Fprintf(&builder, ", use `%s` instead", replacement)
+	}
+	if d.Message != "" {
+		fmt.Fprintf(&builder, ", %s", d.Message)
+	}
+	return builder.String()
+}
+
+// DeprecatedSpec is implemented by the targets, the actions and the flags having the deprecation metadata
+type DeprecatedSpec interface {
+	// Deprecation returns nil if it's not deprecated
+	Deprecation() *Deprecation
+}
+
+// DeprecationOf returns the deprecation metadata of the target, the action or the flag,
+// returns nil if it's not deprecated or it doesn't implement DeprecatedSpec
+func DeprecationOf(item interface{}) *Deprecation {
+	if deprecatedSpec, ok := item.(DeprecatedSpec); ok {
+		return deprecatedSpec.Deprecation()
+	}
+	return nil
+}
+
+// ResolveDeprecations returns the copy of the model in which the deprecated action and flags
+// are replaced by the replacements, and the warnings of the deprecated items used.
+// The value of the deprecated flag is ignored if the replacement flag is present too.
+func ResolveDeprecations(commandSpec ExpModelCommandSpec, model *ExpModel) (*ExpModel, []string) {
+	warnings := make([]string, 0)
+	if deprecation := DeprecationOf(commandSpec); deprecation != nil {
+		warnings = append(warnings, deprecation.Warning("target", commandSpec.Name()))
+	}
+	action := FindAction(commandSpec, model.ActionName)
+	if action == nil {
+		return model, warnings
+	}
+	resolved := *model
+	resolved.ActionFlags = make(map[string]string, len(model.ActionFlags))
+	for name, value := range model.ActionFlags {
+		resolved.ActionFlags[name] = value
+	}
+	flags, _ := EffectiveFlags(commandSpec, action)
+	if deprecation := DeprecationOf(action); deprecation != nil {
+		warnings = append(warnings, deprecation.Warning("action", action.Name()))
+		if replacement := FindAction(commandSpec, deprecation.Replacement); replacement != nil {
+			resolved.ActionName = replacement.Name()
+			replacementFlags, _ := EffectiveFlags(commandSpec, replacement)
+			flags = append(flags, replacementFlags...)
+		}
+	}
+	for _, flag := range flags {
+		deprecation := DeprecationOf(flag)
+		value, ok := resolved.ActionFlags[flag.FlagName()]
+		if deprecation == nil || !ok {
+			continue
+		}
+		warnings = append(warnings, deprecation.Warning("flag", flag.FlagName()))
+		replacement := strings.TrimPrefix(deprecation.Replacement, "--")
+		if replacement == "" {
+			continue
+		}
+		if resolved.ActionFlags[replacement] == "" {
+			resolved.ActionFlags[replacement] = value
+		}
+		delete(resolved.ActionFlags, flag.FlagName())
+	}
+	return &resolved, warnings
+}
+
+// DeprecationMiddleware replaces the deprecated action and flags by the replacements, logs the warnings
+// and appends them to the response. It should be chained before ValidationMiddleware.
+func DeprecationMiddleware(commandSpec ExpModelCommandSpec) Middleware {
+	return func(next Executor) Executor {
+		return WrapExecutor(next, func(uid string, ctx context.Context, model *ExpModel) *Response {
+			resolved, warnings := ResolveDeprecations(commandSpec, model)
+			for _, warning := range warnings {
+				logrus.WithField(Uid, uid).Warn(warning)
+			}
+			response := next.Exec(uid, ctx, resolved)
+			if response != nil && len(warnings) > 0 {
+				response.Warnings = append(response.Warnings, warnings...)
+			}
+			return response
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestDeprecationMiddleware(t *testing.T) {
+	model := &ExpCommandModel{
+		ExpName: "process",
+		ExpActions: []ActionModel{
+			{
+				ActionName:       "stop",
+				ActionFlags:      []ExpFlag{{Name: "process", Required: true}},
+				ActionDeprecated: &Deprecation{Since: "v1.6.0", Replacement: "pause"},
+			},
+			{
+				ActionName: "pause",
+				ActionFlags: []ExpFlag{
+					{Name: "process-name", Required: true},
+					{Name: "process", Deprecated: &Deprecation{Since: "v1.7.0", RemovedIn: "v2.0.0", Replacement: "process-name"}},
+				},
+			},
+		},
+	}
+	var received *ExpModel
+	stub := &fakeExecutor{exec: func(uid string, ctx context.Context, model *ExpModel) *Response {
+		received = model
+		return Success()
+	}}
+	executor := Chain(stub, DeprecationMiddleware(model), ValidationMiddleware(model))
+
+	flags := map[string]string{"process": "java"}
+	response := executor.Exec("e1", context.Background(), &ExpModel{Target: "process", ActionName: "stop", ActionFlags: flags})
+	if !response.Success {
+		t.Fatalf("Exec() = %s, want success", response.Print())
+	}
+	if received.ActionName != "pause" || !reflect.DeepEqual(received.ActionFlags, map[string]string{"process-name": "java"}) {
+		t.Errorf("Exec() received %s %v, want pause with the process-name flag", received.ActionName, received.ActionFlags)
+	}
+	if len(flags) != 1 || flags["process"] != "java" {
+		t.Errorf("the flags of the caller are changed to %v", flags)
+	}
+	wantWarnings := []string{
+		"the `stop` action is deprecated since v1.6.0, use `pause` instead",
+		"the `--process` flag is deprecated since v1.7.0 and will be removed in v2.0.0, use `--process-name` instead",
+	}
+	if !reflect.DeepEqual(response.Warnings, wantWarnings) {
+		t.Errorf("Exec() warnings = %v, want %v", response.Warnings, wantWarnings)
+	}
+}
+
+func TestDeprecationWarning(t *testing.T) {
+	tests := []struct {
+		kind        string
+		name        string
+		replacement string
+		want        string
+	}{
+		{kind: "flag", name: "pid", replacement: "process-id", want: "the `--pid` flag is deprecated, use `--process-id` instead"},
+		{kind: "flag", name: "--pid", replacement: "--process-id", want: "the `--pid` flag is deprecated, use `--process-id` instead"},
+		{kind: "action", name: "stop", replacement: "pause", want: "the `stop` action is deprecated, use `pause` instead"},
+	}
+	for _, tt := range tests {
+		deprecation := &Deprecation{Replacement: tt.replacement}
+		if got := deprecation.Warning(tt.kind, tt.name); got != tt.want {
+			t.Errorf("Warning(%s, %s) = %s, want %s", tt.kind, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBaseSpecDeprecation(t *testing.T) {
+	deprecation := &Deprecation{Since: "v1.6.0"}
+	if got := DeprecationOf(&BaseExpModelCommandSpec{ExpDeprecated: deprecation}); got != deprecation {
+		t.Errorf("DeprecationOf() target = %v, want %v", got, deprecation)
+	}
+	if got := DeprecationOf(&BaseExpActionCommandSpec{ActionDeprecated: deprecation}); got != deprecation {
+		t.Errorf("DeprecationOf() action = %v, want %v", got, deprecation)
+	}
+}
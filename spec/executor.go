@@ -24,6 +24,7 @@ import (
 
 const (
 	DestroyKey = "suid"
+	DryRunKey  = "dry-run"
 )
 
 // ExpModel is the experiment data object
@@ -86,3 +87,13 @@ func IsDestroy(ctx context.Context) (string, bool) {
 	}
 	return suid.(string), true
 }
+
+// SetDryRunFlag marks the experiment invocation as dry run, the channels skip the commands which change the system
+func SetDryRunFlag(ctx context.Context) context.Context {
+	return context.WithValue(ctx, DryRunKey, True)
+}
+
+// IsDryRun returns true if the experiment invocation is dry run
+func IsDryRun(ctx context.Context) bool {
+	return ctx.Value(DryRunKey) == True
+}
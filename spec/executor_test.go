@@ -0,0 +1,45 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import "context"
+
+// fakeExecutor invokes exec, it returns success if exec is nil
+type fakeExecutor struct {
+	exec func(uid string, ctx context.Context, model *ExpModel) *Response
+}
+
+func (e *fakeExecutor) Name() string {
+	return "fake"
+}
+
+func (e *fakeExecutor) Exec(uid string, ctx context.Context, model *ExpModel) *Response {
+	if e.exec == nil {
+		return Success()
+	}
+	return e.exec(uid, ctx, model)
+}
+
+func (e *fakeExecutor) SetChannel(channel Channel) {}
+
+// traceExec appends exec to the trace
+func traceExec(trace *[]string) func(uid string, ctx context.Context, model *ExpModel) *Response {
+	return func(uid string, ctx context.Context, model *ExpModel) *Response {
+		*trace = append(*trace, "exec")
+		return Success()
+	}
+}
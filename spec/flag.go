@@ -0,0 +1,135 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"fmt"
+)
+
+// FlagMergePolicy is how the action flag is merged with the target flag with the same name.
+// The target flags include the flags added by AddFlagsToModelSpec.
+type FlagMergePolicy string
+
+const (
+	// FlagInherit uses the target flag instead of the action flag, the differences are reported as conflicts
+	FlagInherit FlagMergePolicy = "inherit"
+	// FlagOverride keeps the action flag without reporting the conflicts
+	FlagOverride FlagMergePolicy = "override"
+	// FlagHide removes the target flag from the action, the action flag itself is not added either
+	FlagHide FlagMergePolicy = "hide"
+)
+
+// ExpFlagMergeSpec is implemented by the flags declaring the merge policy
+type ExpFlagMergeSpec interface {
+	// FlagMerge returns the merge policy, empty means FlagInherit
+	FlagMerge() FlagMergePolicy
+}
+
+// FlagMerge returns the merge policy of the flag, FlagInherit is returned if it's not declared
+func FlagMerge(flag ExpFlagSpec) FlagMergePolicy {
+	if mergeSpec, ok := flag.(ExpFlagMergeSpec); ok && mergeSpec.FlagMerge() != "" {
+		return mergeSpec.FlagMerge()
+	}
+	return FlagInherit
+}
+
+// FlagConflict is the disagreement between the target flag and the action flag inheriting it,
+// the target flag wins
+type FlagConflict struct {
+	Target string `json:"target"`
+	Action string `json:"action"`
+	Flag   string `json:"flag"`
+	// Field is required, requiredWhenDestroyed or noArgs
+	Field       string `json:"field"`
+	TargetValue bool   `json:"targetValue"`
+	ActionValue bool   `json:"actionValue"`
+}
+
+func (c FlagConflict) String() string {
+	return fmt.Sprintf("%s %s --%s: %s is %t in the target flag, but %t in the action flag",
+		c.Target, c.Action, c.Flag, c.Field, c.TargetValue, c.ActionValue)
+}
+
+// EffectiveFlags returns the flags accepted by the action: the matchers, the action flags and the target flags
+// which are not declared by the action. The first one wins if the names are duplicated in the matchers and
+// the action flags. The target flag is used for the action flag inheriting it, and their differences are
+// returned as the conflicts.
+func EffectiveFlags(commandSpec ExpModelCommandSpec, action ExpActionCommandSpec) ([]ExpFlagSpec, []FlagConflict) {
+	targetFlags := make(map[string]ExpFlagSpec)
+	for _, flag := range commandSpec.Flags() {
+		if _, ok := targetFlags[flag.FlagName()]; !ok {
+			targetFlags[flag.FlagName()] = flag
+		}
+	}
+	flags := make([]ExpFlagSpec, 0)
+	conflicts := make([]FlagConflict, 0)
+	actionFlags := make(map[string]bool)
+	for _, flag := range append(append([]ExpFlagSpec{}, action.Matchers()...), action.Flags()...) {
+		if actionFlags[flag.FlagName()] {
+			continue
+		}
+		actionFlags[flag.FlagName()] = true
+		switch FlagMerge(flag) {
+		case FlagHide:
+			continue
+		case FlagInherit:
+			if targetFlag, ok := targetFlags[flag.FlagName()]; ok {
+				conflicts = append(conflicts, flagConflicts(commandSpec, action, targetFlag, flag)...)
+				flag = targetFlag
+			}
+		}
+		flags = append(flags, flag)
+	}
+	for _, flag := range commandSpec.Flags() {
+		if actionFlags[flag.FlagName()] {
+			continue
+		}
+		actionFlags[flag.FlagName()] = true
+		flags = append(flags, flag)
+	}
+	return flags, conflicts
+}
+
+// flagConflicts returns the differences between the target flag and the action flag inheriting it
+func flagConflicts(commandSpec ExpModelCommandSpec, action ExpActionCommandSpec, targetFlag, actionFlag ExpFlagSpec) []FlagConflict {
+	conflicts := make([]FlagConflict, 0)
+	conflict := FlagConflict{Target: commandSpec.Name(), Action: action.Name(), Flag: targetFlag.FlagName()}
+	for _, field := range []struct {
+		name                     string
+		targetValue, actionValue bool
+	}{
+		{"required", targetFlag.FlagRequired(), actionFlag.FlagRequired()},
+		{"requiredWhenDestroyed", targetFlag.FlagRequiredWhenDestroyed(), actionFlag.FlagRequiredWhenDestroyed()},
+		{"noArgs", targetFlag.FlagNoArgs(), actionFlag.FlagNoArgs()},
+	} {
+		if field.targetValue != field.actionValue {
+			conflict.Field, conflict.TargetValue, conflict.ActionValue = field.name, field.targetValue, field.actionValue
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts
+}
+
+// FlagConflicts returns the flag conflicts of all actions of the command spec
+func FlagConflicts(commandSpec ExpModelCommandSpec) []FlagConflict {
+	conflicts := make([]FlagConflict, 0)
+	for _, action := range commandSpec.Actions() {
+		_, actionConflicts := EffectiveFlags(commandSpec, action)
+		conflicts = append(conflicts, actionConflicts...)
+	}
+	return conflicts
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEffectiveFlags(t *testing.T) {
+	model := &ExpCommandModel{
+		ExpName: "process",
+		ExpActions: []ActionModel{{
+			ActionName:     "kill",
+			ActionMatchers: []ExpFlag{{Name: "process"}},
+			ActionFlags: []ExpFlag{
+				{Name: "timeout", Required: true},
+				{Name: "signal", NoArgs: true, Merge: FlagOverride},
+				{Name: "uid", Merge: FlagHide},
+				{Name: "process", Required: true},
+			},
+		}},
+		ExpFlags: []ExpFlag{
+			{Name: "timeout"},
+			{Name: "signal"},
+			{Name: "uid"},
+			{Name: "debug", NoArgs: true},
+		},
+	}
+	flags, conflicts := EffectiveFlags(model, model.Actions()[0])
+	names := make([]string, 0)
+	for _, flag := range flags {
+		names = append(names, flag.FlagName())
+	}
+	if want := []string{"process", "timeout", "signal", "debug"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("EffectiveFlags() flags = %v, want %v", names, want)
+	}
+	if flags[0].FlagRequired() {
+		t.Errorf("EffectiveFlags() process flag is required, want the matcher declared first")
+	}
+	if flags[1].FlagRequired() {
+		t.Errorf("EffectiveFlags() timeout flag is required, want the inherited target flag")
+	}
+	if !flags[2].FlagNoArgs() {
+		t.Errorf("EffectiveFlags() signal flag is not noArgs, want the overriding action flag")
+	}
+	wantConflicts := []FlagConflict{
+		{Target: "process", Action: "kill", Flag: "timeout", Field: "required", TargetValue: false, ActionValue: true},
+	}
+	if !reflect.DeepEqual(conflicts, wantConflicts) {
+		t.Errorf("EffectiveFlags() conflicts = %v, want %v", conflicts, wantConflicts)
+	}
+	if got := FlagConflicts(model); !reflect.DeepEqual(got, wantConflicts) {
+		t.Errorf("FlagConflicts() = %v, want %v", got, wantConflicts)
+	}
+}
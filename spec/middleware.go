@@ -0,0 +1,200 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ExecFunc has the same signature as Executor.Exec
+type ExecFunc func(uid string, ctx context.Context, model *ExpModel) *Response
+
+// Middleware wraps the executor to add the behaviors shared by all executors, for example logging
+type Middleware func(next Executor) Executor
+
+// wrappedExecutor keeps the name and the channel setter of the wrapped executor
+type wrappedExecutor struct {
+	Executor
+	exec ExecFunc
+}
+
+func (w *wrappedExecutor) Exec(uid string, ctx context.Context, model *ExpModel) *Response {
+	return w.exec(uid, ctx, model)
+}
+
+// WrapExecutor returns the executor which invokes exec instead of next.Exec
+func WrapExecutor(next Executor, exec ExecFunc) Executor {
+	return &wrappedExecutor{Executor: next, exec: exec}
+}
+
+// Chain applies the middlewares to the executor, the first middleware is the outermost one
+func Chain(executor Executor, middlewares ...Middleware) Executor {
+	for idx := len(middlewares) - 1; idx >= 0; idx-- {
+		executor = middlewares[idx](executor)
+	}
+	return executor
+}
+
+// AddMiddlewaresToModelSpec wraps the executors of all actions with the middlewares returned by the function
+// for each spec, so the middlewares requiring the spec, for example ValidationMiddleware, can be installed
+func AddMiddlewaresToModelSpec(middlewaresOf func(expSpec ExpModelCommandSpec) []Middleware, expSpecs ...ExpModelCommandSpec) {
+	for _, expSpec := range expSpecs {
+		middlewares := middlewaresOf(expSpec)
+		// ExpCommandModel overwrites the action executors with ExpExecutor in Actions()
+		if model, ok := expSpec.(*ExpCommandModel); ok && model.ExpExecutor != nil {
+			model.ExpExecutor = Chain(model.ExpExecutor, middlewares...)
+			continue
+		}
+		for _, action := range expSpec.Actions() {
+			if action.Executor() == nil {
+				continue
+			}
+			action.SetExecutor(Chain(action.Executor(), middlewares...))
+		}
+	}
+}
+
+// describe returns the target and the action of the model for logging
+func describe(model *ExpModel) string {
+	if model == nil {
+		return "nil model"
+	}
+	return model.Target + " " + model.ActionName
+}
+
+func operation(ctx context.Context) string {
+	if _, ok := IsDestroy(ctx); ok {
+		return Destroy
+	}
+	return Create
+}
+
+// LoggingMiddleware logs the experiment and the response of each invocation
+func LoggingMiddleware() Middleware {
+	return func(next Executor) Executor {
+		return WrapExecutor(next, func(uid string, ctx context.Context, model *ExpModel) *Response {
+			entry := logrus.WithFields(logrus.Fields{"uid": uid, "executor": next.Name()})
+			if model == nil {
+				entry.Infof("%s experiment, the model is nil", operation(ctx))
+			} else {
+				entry.Infof("%s %s experiment, flags: %s", operation(ctx), describe(model), model.GetFlags())
+			}
+			response := next.Exec(uid, ctx, model)
+			if response == nil {
+				entry.Warnf("%s experiment failed, the executor returns nil response", operation(ctx))
+			} else if response.Success {
+				entry.Infof("%s experiment success, result: %v", operation(ctx), response.Result)
+			} else {
+				entry.Warnf("%s experiment failed, code: %d, err: %s", operation(ctx), response.Code, response.Err)
+			}
+			return response
+		})
+	}
+}
+
+// TimingMiddleware passes the duration of each invocation to the observer, the duration is logged if observer is nil
+func TimingMiddleware(observer func(uid string, model *ExpModel, duration time.Duration, response *Response)) Middleware {
+	return func(next Executor) Executor {
+		return WrapExecutor(next, func(uid string, ctx context.Context, model *ExpModel) *Response {
+			start := time.Now()
+			response := next.Exec(uid, ctx, model)
+			duration := time.Since(start)
+			if observer != nil {
+				observer(uid, model, duration, response)
+			} else {
+				logrus.WithField("uid", uid).Infof("%s %s experiment costs %s", operation(ctx), describe(model), duration)
+			}
+			return response
+		})
+	}
+}
+
+// ValidationMiddleware checks the action exists in the command spec and the required flags are present.
+// The flags required when creating and the flag constraints are checked for create command,
+// and RequiredWhenDestroyed for destroy command.
+func ValidationMiddleware(commandSpec ExpModelCommandSpec) Middleware {
+	return func(next Executor) Executor {
+		return WrapExecutor(next, func(uid string, ctx context.Context, model *ExpModel) *Response {
+			if response, ok := ValidateExpModel(ctx, commandSpec, model); !ok {
+				return response
+			}
+			return next.Exec(uid, ctx, model)
+		})
+	}
+}
+
+// ValidateExpModel returns nil,true if the experiment model satisfies the action of the command spec
+func ValidateExpModel(ctx context.Context, commandSpec ExpModelCommandSpec, model *ExpModel) (*Response, bool) {
+	if model == nil {
+		return ResponseFailWithFlags(ParameterLess, "model"), false
+	}
+	action := FindAction(commandSpec, model.ActionName)
+	if action == nil {
+		return ResponseFailWithFlags(ActionNotSupport, model.ActionName), false
+	}
+	_, destroy := IsDestroy(ctx)
+	flags, _ := EffectiveFlags(commandSpec, action)
+	for _, flag := range flags {
+		value := model.ActionFlags[flag.FlagName()]
+		required := flag.FlagRequired()
+		if destroy {
+			required = flag.FlagRequiredWhenDestroyed()
+		}
+		if required && value == "" {
+			return ResponseFailWithFlags(ParameterLess, flag.FlagName()), false
+		}
+		if flag.FlagNoArgs() && value != "" && value != True && value != False {
+			return ResponseFailWithFlags(ParameterIllegal, flag.FlagName(), value, "the flag value must be true or false"), false
+		}
+	}
+	if destroy {
+		return nil, true
+	}
+	return ValidateFlagConstraints(ActionConstraints(action), model.ActionFlags)
+}
+
+// PermissionMiddleware rejects the invocation with Forbidden code if allow returns false
+func PermissionMiddleware(allow func(ctx context.Context, model *ExpModel) bool) Middleware {
+	return func(next Executor) Executor {
+		return WrapExecutor(next, func(uid string, ctx context.Context, model *ExpModel) *Response {
+			if !allow(ctx, model) {
+				return ResponseFailWithFlags(Forbidden)
+			}
+			return next.Exec(uid, ctx, model)
+		})
+	}
+}
+
+// DryRunMiddleware marks the invocation as dry run by SetDryRunFlag if the dry-run flag of the model is true,
+// so the channels skip the commands which change the system
+func DryRunMiddleware() Middleware {
+	return func(next Executor) Executor {
+		return WrapExecutor(next, func(uid string, ctx context.Context, model *ExpModel) *Response {
+			if model == nil {
+				return next.Exec(uid, ctx, model)
+			}
+			if dryRun, err := strconv.ParseBool(model.ActionFlags[DryRunFlag]); err == nil && dryRun {
+				ctx = SetDryRunFlag(ctx)
+			}
+			return next.Exec(uid, ctx, model)
+		})
+	}
+}
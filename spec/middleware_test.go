@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func traceMiddleware(name string, trace *[]string) Middleware {
+	return func(next Executor) Executor {
+		return WrapExecutor(next, func(uid string, ctx context.Context, model *ExpModel) *Response {
+			*trace = append(*trace, name)
+			return next.Exec(uid, ctx, model)
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	trace := make([]string, 0)
+	executor := Chain(&fakeExecutor{exec: traceExec(&trace)}, traceMiddleware("first", &trace), traceMiddleware("second", &trace))
+	executor.Exec("e1", context.Background(), &ExpModel{})
+	if want := []string{"first", "second", "exec"}; !reflect.DeepEqual(trace, want) {
+		t.Errorf("Chain() invocation order = %v, want %v", trace, want)
+	}
+	if executor.Name() != "fake" {
+		t.Errorf("Chain() executor name = %s, want fake", executor.Name())
+	}
+}
+
+func TestAddMiddlewaresToModelSpec(t *testing.T) {
+	trace := make([]string, 0)
+	model := &ExpCommandModel{
+		ExpName: "process",
+		ExpActions: []ActionModel{{
+			ActionName:     "kill",
+			ActionMatchers: []ExpFlag{{Name: "process", Required: true}},
+			ActionFlags:    []ExpFlag{{Name: "pid", RequiredWhenDestroyed: true}},
+		}},
+		ExpExecutor: &fakeExecutor{exec: traceExec(&trace)},
+	}
+	AddMiddlewaresToModelSpec(func(expSpec ExpModelCommandSpec) []Middleware {
+		return []Middleware{ValidationMiddleware(expSpec)}
+	}, model)
+	executor := model.Actions()[0].Executor()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		flags   map[string]string
+		success bool
+	}{
+		{name: "create without required flag", ctx: context.Background(), flags: map[string]string{}},
+		{name: "create", ctx: context.Background(), flags: map[string]string{"process": "java"}, success: true},
+		{name: "destroy without required flag", ctx: SetDestroyFlag(context.Background(), "e1"), flags: map[string]string{}},
+		{name: "destroy", ctx: SetDestroyFlag(context.Background(), "e1"), flags: map[string]string{"pid": "1"}, success: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := executor.Exec("e1", tt.ctx, &ExpModel{Target: "process", ActionName: "kill", ActionFlags: tt.flags})
+			if response.Success != tt.success {
+				t.Errorf("Exec() = %s, want success %t", response.Print(), tt.success)
+			}
+		})
+	}
+}
+
+func TestDryRunMiddleware(t *testing.T) {
+	tests := []struct {
+		flags map[string]string
+		want  bool
+	}{
+		{flags: map[string]string{DryRunFlag: "true"}, want: true},
+		{flags: map[string]string{DryRunFlag: "false"}},
+		{flags: map[string]string{DryRunFlag: "yes"}},
+		{},
+	}
+	for _, tt := range tests {
+		var dryRun bool
+		executor := Chain(&fakeExecutor{}, DryRunMiddleware(), func(next Executor) Executor {
+			return WrapExecutor(next, func(uid string, ctx context.Context, model *ExpModel) *Response {
+				dryRun = IsDryRun(ctx)
+				return next.Exec(uid, ctx, model)
+			})
+		})
+		executor.Exec("e1", context.Background(), &ExpModel{Target: "cpu", ActionName: "fullload", ActionFlags: tt.flags})
+		if dryRun != tt.want {
+			t.Errorf("IsDryRun() with flags %v = %t, want %t", tt.flags, dryRun, tt.want)
+		}
+	}
+}
+
+func TestMiddlewaresNilModelAndResponse(t *testing.T) {
+	model := &ExpCommandModel{ExpName: "cpu", ExpActions: []ActionModel{{ActionName: "fullload"}}}
+	nilExecutor := WrapExecutor(&fakeExecutor{}, func(uid string, ctx context.Context, model *ExpModel) *Response {
+		return nil
+	})
+	executor := Chain(nilExecutor, LoggingMiddleware(), TimingMiddleware(nil), DryRunMiddleware())
+	for _, ctx := range []context.Context{context.Background(), SetDestroyFlag(context.Background(), "e1")} {
+		if response := executor.Exec("e1", ctx, nil); response != nil {
+			t.Errorf("Exec() nil model = %v, want the nil response passed through", response)
+		}
+		if response := executor.Exec("e1", ctx, &ExpModel{Target: "cpu", ActionName: "fullload"}); response != nil {
+			t.Errorf("Exec() = %v, want the nil response passed through", response)
+		}
+	}
+	if response := Chain(nilExecutor, ValidationMiddleware(model)).Exec("e1", context.Background(), nil); response.Code != ParameterLess.Code {
+		t.Errorf("Exec() nil model validation = %v, want ParameterLess", response)
+	}
+}
@@ -126,6 +126,19 @@ type ExpFlag struct {
 
 	// default value
 	Default string `yaml:"default,omitempty"`
+
+	// Merge is how the action flag is merged with the target flag with the same name, inherit by default
+	Merge FlagMergePolicy `yaml:"merge,omitempty"`
+
+	// Deprecated is not nil if the flag is deprecated
+	Deprecated *Deprecation `yaml:"deprecated,omitempty"`
+}
+
+// ExpFlagEnumSpec is implemented by the flag specs accepting the enumerated values only,
+// ExpFlag doesn't implement it
+type ExpFlagEnumSpec interface {
+	// FlagEnum returns the values accepted by the flag
+	FlagEnum() []string
 }
 
 func (f *ExpFlag) FlagName() string {
@@ -152,11 +165,28 @@ func (f *ExpFlag) FlagDefault() string {
 	return f.Default
 }
 
+func (f *ExpFlag) FlagMerge() FlagMergePolicy {
+	return f.Merge
+}
+
+func (f *ExpFlag) Deprecation() *Deprecation {
+	return f.Deprecated
+}
+
+// FlagEnum returns the enumerated values of the flag, returns nil if the flag doesn't implement ExpFlagEnumSpec
+func FlagEnum(flag ExpFlagSpec) []string {
+	if enumSpec, ok := flag.(ExpFlagEnumSpec); ok {
+		return enumSpec.FlagEnum()
+	}
+	return nil
+}
+
 // BaseExpModelCommandSpec defines the common struct of the implementation of ExpModelCommandSpec
 type BaseExpModelCommandSpec struct {
-	ExpScope   string
-	ExpActions []ExpActionCommandSpec
-	ExpFlags   []ExpFlagSpec
+	ExpScope      string
+	ExpActions    []ExpActionCommandSpec
+	ExpFlags      []ExpFlagSpec
+	ExpDeprecated *Deprecation
 }
 
 // Scope default value is "" means localhost
@@ -176,16 +206,23 @@ func (b *BaseExpModelCommandSpec) SetFlags(flags []ExpFlagSpec) {
 	b.ExpFlags = flags
 }
 
+func (b *BaseExpModelCommandSpec) Deprecation() *Deprecation {
+	return b.ExpDeprecated
+}
+
 // BaseExpActionCommandSpec defines the common struct of the implementation of ExpActionCommandSpec
 type BaseExpActionCommandSpec struct {
-	ActionMatchers    []ExpFlagSpec
-	ActionFlags       []ExpFlagSpec
-	ActionExecutor    Executor
-	ActionLongDesc    string
-	ActionExample     string
-	ActionPrograms    []string
-	ActionCategories  []string
-	ActionProcessHang bool
+	ActionMatchers     []ExpFlagSpec
+	ActionFlags        []ExpFlagSpec
+	ActionExecutor     Executor
+	ActionLongDesc     string
+	ActionExample      string
+	ActionPrograms     []string
+	ActionCategories   []string
+	ActionProcessHang  bool
+	ActionConstraints  []FlagConstraint
+	ActionRequirements *ActionRequirements
+	ActionDeprecated   *Deprecation
 }
 
 func (b *BaseExpActionCommandSpec) Matchers() []ExpFlagSpec {
@@ -232,6 +269,18 @@ func (b *BaseExpActionCommandSpec) ProcessHang() bool {
 	return b.ActionProcessHang
 }
 
+func (b *BaseExpActionCommandSpec) Constraints() []FlagConstraint {
+	return b.ActionConstraints
+}
+
+func (b *BaseExpActionCommandSpec) Requirements() *ActionRequirements {
+	return b.ActionRequirements
+}
+
+func (b *BaseExpActionCommandSpec) Deprecation() *Deprecation {
+	return b.ActionDeprecated
+}
+
 // ActionModel for yaml file
 type ActionModel struct {
 	ActionName        string    `yaml:"action"`
@@ -245,6 +294,12 @@ type ActionModel struct {
 	ActionPrograms    []string `yaml:"programs,omitempty"`
 	ActionCategories  []string `yaml:"categories,omitempty"`
 	ActionProcessHang bool     `yaml:"actionProcessHang"`
+	// ActionConstraints are the rules between the flags, for example oneOf pid and process
+	ActionConstraints []FlagConstraint `yaml:"constraints,omitempty"`
+	// ActionDeprecated is not nil if the action is deprecated
+	ActionDeprecated *Deprecation `yaml:"deprecated,omitempty"`
+	// ActionRequirements are checked before the experiment is created
+	ActionRequirements *ActionRequirements `yaml:"requirements,omitempty"`
 }
 
 func (am *ActionModel) Programs() []string {
@@ -315,6 +370,18 @@ func (am *ActionModel) ProcessHang() bool {
 	return am.ActionProcessHang
 }
 
+func (am *ActionModel) Constraints() []FlagConstraint {
+	return am.ActionConstraints
+}
+
+func (am *ActionModel) Deprecation() *Deprecation {
+	return am.ActionDeprecated
+}
+
+func (am *ActionModel) Requirements() *ActionRequirements {
+	return am.ActionRequirements
+}
+
 type ExpPrepareModel struct {
 	PrepareType     string    `yaml:"type"`
 	PrepareFlags    []ExpFlag `yaml:"flags"`
@@ -331,6 +398,14 @@ type ExpCommandModel struct {
 	ExpScope        string          `yaml:"scope"`
 	ExpPrepareModel ExpPrepareModel `yaml:"prepare,omitempty"`
 	ExpSubTargets   []string        `yaml:"subTargets,flow,omitempty"`
+	// ExpSubModels are the child targets, they inherit the flags and the scope of the model
+	ExpSubModels []ExpCommandModel `yaml:"subModels,omitempty"`
+	// ExpDeprecated is not nil if the target is deprecated
+	ExpDeprecated *Deprecation `yaml:"deprecated,omitempty"`
+}
+
+func (ecm *ExpCommandModel) Deprecation() *Deprecation {
+	return ecm.ExpDeprecated
 }
 
 func (ecm *ExpCommandModel) Scope() string {
@@ -439,6 +514,21 @@ func AddFlagsToModelSpec(flagsFunc func() []ExpFlagSpec, expSpecs ...ExpModelCom
 	}
 }
 
+// FindAction returns the action of the command spec by the action name or alias, returns nil if not found
+func FindAction(commandSpec ExpModelCommandSpec, actionName string) ExpActionCommandSpec {
+	for _, action := range commandSpec.Actions() {
+		if action.Name() == actionName {
+			return action
+		}
+		for _, alias := range action.Aliases() {
+			if alias == actionName {
+				return action
+			}
+		}
+	}
+	return nil
+}
+
 // AddExecutorToModelSpec
 func AddExecutorToModelSpec(executor Executor, expSpecs ...ExpModelCommandSpec) {
 	for _, expSpec := range expSpecs {
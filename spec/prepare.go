@@ -0,0 +1,338 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PrepareModel is the prepare data object, for example attaching the java agent to the process
+type PrepareModel struct {
+	// Target is the experiment target which requires the prepare step, for example jvm
+	Target string `json:"target,omitempty"`
+
+	// PrepareType is the ExpPrepareModel type
+	PrepareType string `json:"type,omitempty"`
+
+	// ActionFlags is the prepare flags declared by ExpPrepareModel.PrepareFlags
+	ActionFlags map[string]string `json:"flags,omitempty"`
+}
+
+// Preparer defines the prepare step of the target whose ExpPrepareModel is required
+type Preparer interface {
+	// Name is used to identify the Preparer
+	Name() string
+
+	// Prepare is used to run the prepare step
+	Prepare(uid string, ctx context.Context, model *PrepareModel) *Response
+
+	// Revoke is used to undo the prepare step
+	Revoke(uid string, ctx context.Context, model *PrepareModel) *Response
+
+	// Status returns success if the prepare step is still in effect
+	Status(uid string, ctx context.Context, model *PrepareModel) *Response
+
+	// SetChannel
+	SetChannel(channel Channel)
+}
+
+// PrepareRecord is the status record of the prepare step identified by uid.
+// StatusRunning means the prepare step is in effect.
+type PrepareRecord struct {
+	Uid        string        `json:"uid"`
+	Model      *PrepareModel `json:"model,omitempty"`
+	Status     string        `json:"status"`
+	Error      string        `json:"error,omitempty"`
+	CreateTime time.Time     `json:"createTime"`
+	UpdateTime time.Time     `json:"updateTime"`
+}
+
+// Transit changes the record status if the transition is allowed, errMsg is only kept for StatusError
+func (r *PrepareRecord) Transit(status, errMsg string) error {
+	return transitStatus(&r.Status, &r.Error, &r.UpdateTime, status, errMsg)
+}
+
+// copyPrepareRecord returns a deep copy to prevent the stored record being modified by callers
+func copyPrepareRecord(record *PrepareRecord) *PrepareRecord {
+	copied := *record
+	if record.Model != nil {
+		model := *record.Model
+		model.ActionFlags = copyFlags(record.Model.ActionFlags)
+		copied.Model = &model
+	}
+	return &copied
+}
+
+func (r *PrepareRecord) prepareType() string {
+	if r.Model == nil {
+		return ""
+	}
+	return r.Model.PrepareType
+}
+
+// PrepareStore persists the prepare records, the errors returned are *Response as ExperimentStore
+type PrepareStore interface {
+	// Create saves the new record
+	Create(record *PrepareRecord) error
+
+	// UpdateStatus changes the record status by uid
+	UpdateStatus(uid, status, errMsg string) error
+
+	// Get returns the record by uid
+	Get(uid string) (*PrepareRecord, error)
+
+	// QueryByType returns the records of the target and the prepare type, ordered by create time descending
+	QueryByType(target, prepareType string) ([]*PrepareRecord, error)
+}
+
+// MemoryPrepareStore keeps the prepare records in memory
+type MemoryPrepareStore struct {
+	lock    sync.RWMutex
+	records map[string]*PrepareRecord
+}
+
+func NewMemoryPrepareStore() *MemoryPrepareStore {
+	return &MemoryPrepareStore{
+		records: make(map[string]*PrepareRecord),
+	}
+}
+
+func (m *MemoryPrepareStore) Create(record *PrepareRecord) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if record == nil || record.Uid == "" {
+		return ResponseFailWithFlags(ParameterLess, Uid)
+	}
+	if _, ok := m.records[record.Uid]; ok {
+		return ResponseFailWithFlags(DataExists, record.Uid)
+	}
+	m.records[record.Uid] = copyPrepareRecord(record)
+	return nil
+}
+
+func (m *MemoryPrepareStore) UpdateStatus(uid, status, errMsg string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	record, ok := m.records[uid]
+	if !ok {
+		return ResponseFailWithFlags(DataNotFound, uid)
+	}
+	updated := copyPrepareRecord(record)
+	if err := updated.Transit(status, errMsg); err != nil {
+		return ReturnFail(StatusTransitionIllegal, err.Error())
+	}
+	m.records[uid] = updated
+	return nil
+}
+
+func (m *MemoryPrepareStore) Get(uid string) (*PrepareRecord, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	record, ok := m.records[uid]
+	if !ok {
+		return nil, ResponseFailWithFlags(DataNotFound, uid)
+	}
+	return copyPrepareRecord(record), nil
+}
+
+func (m *MemoryPrepareStore) QueryByType(target, prepareType string) ([]*PrepareRecord, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	records := make([]*PrepareRecord, 0)
+	for _, record := range m.records {
+		if record.Model == nil || record.Model.Target != target || record.Model.PrepareType != prepareType {
+			continue
+		}
+		records = append(records, copyPrepareRecord(record))
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreateTime.After(records[j].CreateTime)
+	})
+	return records, nil
+}
+
+// ValidatePrepareFlags returns nil,true if the flags satisfy the PrepareFlags of the prepare model
+func ValidatePrepareFlags(prepare ExpPrepareModel, flags map[string]string) (*Response, bool) {
+	declared := make(map[string]ExpFlag, len(prepare.PrepareFlags))
+	for _, flag := range prepare.PrepareFlags {
+		declared[flag.Name] = flag
+		value, ok := flags[flag.Name]
+		if flag.Required && (!ok || value == "") {
+			return ResponseFailWithFlags(ParameterLess, flag.Name), false
+		}
+		if flag.NoArgs && ok && value != "" && value != True && value != False {
+			return ResponseFailWithFlags(ParameterIllegal, flag.Name, value, "the flag value must be true or false"), false
+		}
+	}
+	for name := range flags {
+		if _, ok := declared[name]; !ok {
+			return ResponseFailWithFlags(ParameterIllegal, name, flags[name],
+				fmt.Sprintf("the flag is not declared by `%s` prepare", prepare.PrepareType)), false
+		}
+	}
+	return nil, true
+}
+
+// PrepareManager runs the prepare steps and tracks them by uid
+type PrepareManager struct {
+	store PrepareStore
+}
+
+func NewPrepareManager(store PrepareStore) *PrepareManager {
+	return &PrepareManager{store: store}
+}
+
+// Prepare validates the flags, runs the prepare step and records the result
+func (pm *PrepareManager) Prepare(uid string, ctx context.Context, preparer Preparer, prepare ExpPrepareModel,
+	model *PrepareModel,
+) *Response {
+	if preparer == nil {
+		return ResponseFailWithFlags(HandlerExecNotFound, prepare.PrepareType)
+	}
+	// the caller's model is not changed
+	copied := PrepareModel{}
+	if model != nil {
+		copied = *model
+		copied.ActionFlags = copyFlags(model.ActionFlags)
+	}
+	model = &copied
+	if model.PrepareType == "" {
+		model.PrepareType = prepare.PrepareType
+	}
+	if resp, ok := ValidatePrepareFlags(prepare, model.ActionFlags); !ok {
+		return resp
+	}
+	now := time.Now()
+	record := &PrepareRecord{
+		Uid:        uid,
+		Model:      model,
+		Status:     StatusCreated,
+		CreateTime: now,
+		UpdateTime: now,
+	}
+	if err := pm.store.Create(record); err != nil {
+		return StoreErrorToResponse("insert", err)
+	}
+	response := checkPrepareResponse(preparer, preparer.Prepare(uid, ctx, model))
+	if response.Success {
+		pm.updateStatus(uid, StatusRunning, "")
+	} else {
+		pm.updateStatus(uid, StatusError, response.Err)
+	}
+	return response
+}
+
+// Revoke undoes the prepare step by uid
+func (pm *PrepareManager) Revoke(uid string, ctx context.Context, preparer Preparer) *Response {
+	record, err := pm.store.Get(uid)
+	if err != nil {
+		return StoreErrorToResponse("query", err)
+	}
+	if record.Status == StatusRevoked {
+		return ReturnSuccess(record)
+	}
+	if preparer == nil {
+		return ResponseFailWithFlags(HandlerExecNotFound, record.prepareType())
+	}
+	response := checkPrepareResponse(preparer, preparer.Revoke(uid, ctx, record.Model))
+	if response.Success {
+		pm.updateStatus(uid, StatusRevoked, "")
+	} else {
+		pm.updateStatus(uid, StatusError, response.Err)
+	}
+	return response
+}
+
+// Status queries the prepare step by uid, it returns success only if the prepare step is still in effect.
+// The record is marked as error if the preparer reports the prepare step is no longer in effect.
+func (pm *PrepareManager) Status(uid string, ctx context.Context, preparer Preparer) *Response {
+	record, err := pm.store.Get(uid)
+	if err != nil {
+		return StoreErrorToResponse("query", err)
+	}
+	if record.Status != StatusRunning {
+		return ResponseFailWithFlags(UnexpectedStatus, StatusRunning, record.Status)
+	}
+	if preparer == nil {
+		return ResponseFailWithFlags(HandlerExecNotFound, record.prepareType())
+	}
+	response := checkPrepareResponse(preparer, preparer.Status(uid, ctx, record.Model))
+	if !response.Success {
+		pm.updateStatus(uid, StatusError, response.Err)
+		return response
+	}
+	return ReturnSuccess(record)
+}
+
+// checkPrepareResponse returns the failure response if the preparer returns nil
+func checkPrepareResponse(preparer Preparer, response *Response) *Response {
+	if response == nil {
+		return ResponseFailWithFlags(ResultUnmarshalFailed, preparer.Name(), "the preparer returns nil response")
+	}
+	return response
+}
+
+func (pm *PrepareManager) updateStatus(uid, status, errMsg string) {
+	if err := pm.store.UpdateStatus(uid, status, errMsg); err != nil {
+		logrus.WithField("uid", uid).Warnf("update the prepare record status to %s failed, %v", status, err)
+	}
+}
+
+// CheckPrepared returns nil,true if the command model does not require the prepare step,
+// or there is a prepare step of the target in effect
+func (pm *PrepareManager) CheckPrepared(commandModel *ExpCommandModel) (*Response, bool) {
+	prepare := commandModel.ExpPrepareModel
+	if !prepare.PrepareRequired {
+		return nil, true
+	}
+	records, err := pm.store.QueryByType(commandModel.ExpName, prepare.PrepareType)
+	if err != nil {
+		return StoreErrorToResponse("query", err), false
+	}
+	for _, record := range records {
+		if record.Status == StatusRunning {
+			return nil, true
+		}
+	}
+	return ResponseFailWithFlags(PrepareNotReady, commandModel.ExpName, prepare.PrepareType), false
+}
+
+// Guard returns the executor which refuses to create the experiment if CheckPrepared fails,
+// the destroy command is always passed through
+func (pm *PrepareManager) Guard(executor Executor, commandModel *ExpCommandModel) Executor {
+	return WrapExecutor(executor, func(uid string, ctx context.Context, model *ExpModel) *Response {
+		if _, ok := IsDestroy(ctx); !ok {
+			if resp, ok := pm.CheckPrepared(commandModel); !ok {
+				return resp
+			}
+		}
+		return executor.Exec(uid, ctx, model)
+	})
+}
+
+// Middleware returns the middleware form of Guard
+func (pm *PrepareManager) Middleware(commandModel *ExpCommandModel) Middleware {
+	return func(next Executor) Executor {
+		return pm.Guard(next, commandModel)
+	}
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"context"
+	"testing"
+)
+
+// fakePreparer returns the responses by method, the nil response is returned if the method is absent
+type fakePreparer struct {
+	responses map[string]*Response
+}
+
+func (p *fakePreparer) Name() string {
+	return "fake"
+}
+
+func (p *fakePreparer) Prepare(uid string, ctx context.Context, model *PrepareModel) *Response {
+	return p.responses["prepare"]
+}
+
+func (p *fakePreparer) Revoke(uid string, ctx context.Context, model *PrepareModel) *Response {
+	return p.responses["revoke"]
+}
+
+func (p *fakePreparer) Status(uid string, ctx context.Context, model *PrepareModel) *Response {
+	return p.responses["status"]
+}
+
+func (p *fakePreparer) SetChannel(channel Channel) {}
+
+var jvmPrepare = ExpPrepareModel{
+	PrepareType:     "jvm",
+	PrepareRequired: true,
+	PrepareFlags: []ExpFlag{
+		{Name: "pid", Required: true},
+		{Name: "async", NoArgs: true},
+	},
+}
+
+func TestValidatePrepareFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		wantCode int32
+	}{
+		{name: "valid", flags: map[string]string{"pid": "100", "async": "true"}},
+		{name: "required absent", flags: map[string]string{"async": "true"}, wantCode: ParameterLess.Code},
+		{name: "no args value", flags: map[string]string{"pid": "100", "async": "yes"}, wantCode: ParameterIllegal.Code},
+		{name: "undeclared", flags: map[string]string{"pid": "100", "port": "8080"}, wantCode: ParameterIllegal.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, ok := ValidatePrepareFlags(jvmPrepare, tt.flags)
+			if ok != (tt.wantCode == 0) || (!ok && response.Code != tt.wantCode) {
+				t.Errorf("ValidatePrepareFlags() = %v, %t, want code %d", response, ok, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPrepareManager(t *testing.T) {
+	ctx := context.Background()
+	success := &fakePreparer{responses: map[string]*Response{
+		"prepare": ReturnSuccess("attached"), "revoke": ReturnSuccess("detached"), "status": ReturnSuccess("running"),
+	}}
+	store := NewMemoryPrepareStore()
+	manager := NewPrepareManager(store)
+	flags := map[string]string{"pid": "100"}
+	model := &PrepareModel{Target: "jvm", ActionFlags: flags}
+
+	if response := manager.Prepare("p1", ctx, success, jvmPrepare, model); !response.Success {
+		t.Fatalf("Prepare() = %v, want success", response)
+	}
+	if model.PrepareType != "" {
+		t.Errorf("Prepare() changes the caller's model, type = %s", model.PrepareType)
+	}
+	// the stored record doesn't share the flags with the caller
+	flags["pid"] = "200"
+	record, _ := store.Get("p1")
+	if record.Status != StatusRunning || record.Model.PrepareType != "jvm" || record.Model.ActionFlags["pid"] != "100" {
+		t.Errorf("Get() = %+v, model %+v, want running jvm prepare of pid 100", record, record.Model)
+	}
+	record.Model.ActionFlags["pid"] = "300"
+	if record, _ := store.Get("p1"); record.Model.ActionFlags["pid"] != "100" {
+		t.Errorf("Get() returns the stored flags, pid = %s", record.Model.ActionFlags["pid"])
+	}
+
+	tests := []struct {
+		name     string
+		invoke   func() *Response
+		wantCode int32
+	}{
+		{name: "prepare nil preparer", invoke: func() *Response {
+			return manager.Prepare("p2", ctx, nil, jvmPrepare, &PrepareModel{})
+		}, wantCode: HandlerExecNotFound.Code},
+		{name: "prepare nil model", invoke: func() *Response {
+			return manager.Prepare("p2", ctx, success, jvmPrepare, nil)
+		}, wantCode: ParameterLess.Code},
+		{name: "prepare nil response", invoke: func() *Response {
+			return manager.Prepare("p3", ctx, &fakePreparer{}, jvmPrepare, &PrepareModel{Target: "jvm", ActionFlags: map[string]string{"pid": "1"}})
+		}, wantCode: ResultUnmarshalFailed.Code},
+		{name: "status nil preparer", invoke: func() *Response {
+			return manager.Status("p1", ctx, nil)
+		}, wantCode: HandlerExecNotFound.Code},
+		{name: "status", invoke: func() *Response {
+			return manager.Status("p1", ctx, success)
+		}, wantCode: OK.Code},
+		{name: "status unknown uid", invoke: func() *Response {
+			return manager.Status("unknown", ctx, success)
+		}, wantCode: DataNotFound.Code},
+		{name: "revoke nil preparer", invoke: func() *Response {
+			return manager.Revoke("p1", ctx, nil)
+		}, wantCode: HandlerExecNotFound.Code},
+		{name: "revoke", invoke: func() *Response {
+			return manager.Revoke("p1", ctx, success)
+		}, wantCode: OK.Code},
+		{name: "revoke again", invoke: func() *Response {
+			return manager.Revoke("p1", ctx, nil)
+		}, wantCode: OK.Code},
+		{name: "status revoked", invoke: func() *Response {
+			return manager.Status("p1", ctx, success)
+		}, wantCode: UnexpectedStatus.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if response := tt.invoke(); response.Code != tt.wantCode {
+				t.Errorf("response = %v, want code %d", response, tt.wantCode)
+			}
+		})
+	}
+	if record, _ := store.Get("p3"); record.Status != StatusError {
+		t.Errorf("Get() status = %s, want %s for the nil response", record.Status, StatusError)
+	}
+	if record, _ := store.Get("p1"); record.Status != StatusRevoked {
+		t.Errorf("Get() status = %s, want %s", record.Status, StatusRevoked)
+	}
+}
+
+func TestPrepareManagerStatusLost(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryPrepareStore()
+	manager := NewPrepareManager(store)
+	preparer := &fakePreparer{responses: map[string]*Response{
+		"prepare": ReturnSuccess("attached"), "status": ResponseFailWithFlags(ProcessNotExist, "100"),
+	}}
+	manager.Prepare("p1", ctx, preparer, jvmPrepare, &PrepareModel{Target: "jvm", ActionFlags: map[string]string{"pid": "100"}})
+	if response := manager.Status("p1", ctx, preparer); response.Success {
+		t.Errorf("Status() = %v, want failure", response)
+	}
+	if record, _ := store.Get("p1"); record.Status != StatusError {
+		t.Errorf("Get() status = %s, want %s", record.Status, StatusError)
+	}
+}
+
+func TestPrepareManagerGuard(t *testing.T) {
+	ctx := context.Background()
+	trace := make([]string, 0)
+	manager := NewPrepareManager(NewMemoryPrepareStore())
+	commandModel := &ExpCommandModel{ExpName: "jvm", ExpPrepareModel: jvmPrepare}
+	executor := manager.Guard(&fakeExecutor{exec: traceExec(&trace)}, commandModel)
+
+	if _, ok := manager.CheckPrepared(&ExpCommandModel{ExpName: "cpu"}); !ok {
+		t.Errorf("CheckPrepared() = false, want true for the target not requiring prepare")
+	}
+	if response := executor.Exec("e1", ctx, &ExpModel{Target: "jvm"}); response.Code != PrepareNotReady.Code {
+		t.Errorf("Exec() = %v, want PrepareNotReady", response)
+	}
+	if response := executor.Exec("e1", SetDestroyFlag(ctx, "e1"), &ExpModel{Target: "jvm"}); !response.Success {
+		t.Errorf("Exec() destroy = %v, want success", response)
+	}
+	preparer := &fakePreparer{responses: map[string]*Response{"prepare": ReturnSuccess("attached")}}
+	manager.Prepare("p1", ctx, preparer, jvmPrepare, &PrepareModel{Target: "jvm", ActionFlags: map[string]string{"pid": "100"}})
+	if _, ok := manager.CheckPrepared(commandModel); !ok {
+		t.Errorf("CheckPrepared() = false, want true after prepare")
+	}
+	if response := executor.Exec("e2", ctx, &ExpModel{Target: "jvm"}); !response.Success {
+		t.Errorf("Exec() = %v, want success after prepare", response)
+	}
+	if len(trace) != 2 {
+		t.Errorf("executor invoked %d times, want 2", len(trace))
+	}
+}
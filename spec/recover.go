@@ -0,0 +1,76 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"context"
+	"reflect"
+	"runtime/debug"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RecoverToResponse logs the recovered panic with the stack and returns the PanicRecovered failure response.
+// It must be called with the value returned by recover() in the deferred function.
+// The panic is logged by logrus directly, because the log package imports spec.
+func RecoverToResponse(ctx context.Context, name string, recovered interface{}) *Response {
+	logrus.WithField(Uid, ctx.Value(Uid)).Errorf("`%s` panic: %v\n%s", name, recovered, debug.Stack())
+	return ResponseFailWithFlags(PanicRecovered, name, recovered)
+}
+
+// SafeExec invokes the executor, the panic in it is converted to the failure response
+func SafeExec(uid string, ctx context.Context, executor Executor, model *ExpModel) (response *Response) {
+	if isNilValue(executor) {
+		target := ""
+		if model != nil {
+			target = model.Target
+		}
+		return ResponseFailWithFlags(HandlerExecNotFound, target)
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			response = RecoverToResponse(withUid(ctx, uid), executor.Name(), recovered)
+		}
+	}()
+	return executor.Exec(uid, ctx, model)
+}
+
+// RecoveryMiddleware returns the middleware form of SafeExec
+func RecoveryMiddleware() Middleware {
+	return func(next Executor) Executor {
+		return WrapExecutor(next, func(uid string, ctx context.Context, model *ExpModel) *Response {
+			return SafeExec(uid, ctx, next, model)
+		})
+	}
+}
+
+// withUid sets the uid to the context for logging if absent
+func withUid(ctx context.Context, uid string) context.Context {
+	if ctx.Value(Uid) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, Uid, uid)
+}
+
+// isNilValue returns true if the interface is nil or holds a nil pointer
+func isNilValue(i interface{}) bool {
+	if i == nil {
+		return true
+	}
+	v := reflect.ValueOf(i)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
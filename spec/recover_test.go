@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"context"
+	"testing"
+)
+
+func panicExec(uid string, ctx context.Context, model *ExpModel) *Response {
+	panic("boom")
+}
+
+func TestSafeExec(t *testing.T) {
+	var nilExecutor *fakeExecutor
+	tests := []struct {
+		name     string
+		executor Executor
+		model    *ExpModel
+		wantCode int32
+	}{
+		{name: "success", executor: &fakeExecutor{}, model: &ExpModel{Target: "cpu"}, wantCode: OK.Code},
+		{name: "panic", executor: &fakeExecutor{exec: panicExec}, model: &ExpModel{Target: "cpu"}, wantCode: PanicRecovered.Code},
+		{name: "nil executor", model: &ExpModel{Target: "cpu"}, wantCode: HandlerExecNotFound.Code},
+		{name: "nil pointer executor", executor: nilExecutor, model: &ExpModel{Target: "cpu"}, wantCode: HandlerExecNotFound.Code},
+		{name: "nil executor and model", wantCode: HandlerExecNotFound.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if response := SafeExec("e1", context.Background(), tt.executor, tt.model); response.Code != tt.wantCode {
+				t.Errorf("SafeExec() = %v, want code %d", response, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	executor := Chain(&fakeExecutor{exec: panicExec}, RecoveryMiddleware())
+	if response := executor.Exec("e1", context.Background(), &ExpModel{Target: "cpu"}); response.Code != PanicRecovered.Code {
+		t.Errorf("Exec() = %v, want code %d", response, PanicRecovered.Code)
+	}
+}
+
+func TestRecoverToResponse(t *testing.T) {
+	response := func() (response *Response) {
+		defer func() {
+			response = RecoverToResponse(context.Background(), "test", recover())
+		}()
+		panic("boom")
+	}()
+	if response.Code != PanicRecovered.Code || response.Err != PanicRecovered.Sprintf("test", "boom") {
+		t.Errorf("RecoverToResponse() = %v, want the PanicRecovered response", response)
+	}
+}
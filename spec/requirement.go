@@ -0,0 +1,85 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import "strings"
+
+// ActionRequirements are the platform and privilege requirements of the action, for example:
+//
+//	requirements:
+//	  os: [linux]
+//	  root: true
+//	  capabilities: [CAP_NET_ADMIN]
+//	  commands: [tc]
+//	  kernelVersion: "4.9"
+type ActionRequirements struct {
+	// OS is the operating systems supported, for example linux, any os is supported if it's empty
+	OS []string `yaml:"os,flow,omitempty" json:"os,omitempty"`
+	// Arch is the architectures supported in GOARCH form, for example amd64 and arm64
+	Arch []string `yaml:"arch,flow,omitempty" json:"arch,omitempty"`
+	// Root is true if the action must be executed by root
+	Root bool `yaml:"root,omitempty" json:"root,omitempty"`
+	// Capabilities are the linux capabilities required, for example CAP_NET_ADMIN
+	Capabilities []string `yaml:"capabilities,flow,omitempty" json:"capabilities,omitempty"`
+	// Commands are the commands required, for example tc and iptables
+	Commands []string `yaml:"commands,flow,omitempty" json:"commands,omitempty"`
+	// KernelVersion is the minimum kernel version, for example 4.9
+	KernelVersion string `yaml:"kernelVersion,omitempty" json:"kernelVersion,omitempty"`
+	// CgroupV2 is true if the action requires the cgroup v2 unified hierarchy
+	CgroupV2 bool `yaml:"cgroupV2,omitempty" json:"cgroupV2,omitempty"`
+}
+
+// Strings returns the requirements declared in readable form, for example "commands: tc, iptables"
+func (r *ActionRequirements) Strings() []string {
+	requirements := make([]string, 0)
+	if len(r.OS) > 0 {
+		requirements = append(requirements, "os: "+strings.Join(r.OS, ", "))
+	}
+	if len(r.Arch) > 0 {
+		requirements = append(requirements, "arch: "+strings.Join(r.Arch, ", "))
+	}
+	if r.Root {
+		requirements = append(requirements, "root")
+	}
+	if len(r.Capabilities) > 0 {
+		requirements = append(requirements, "capabilities: "+strings.Join(r.Capabilities, ", "))
+	}
+	if len(r.Commands) > 0 {
+		requirements = append(requirements, "commands: "+strings.Join(r.Commands, ", "))
+	}
+	if r.KernelVersion != "" {
+		requirements = append(requirements, "kernel version: >= "+r.KernelVersion)
+	}
+	if r.CgroupV2 {
+		requirements = append(requirements, "cgroup v2")
+	}
+	return requirements
+}
+
+// ExpActionRequirementSpec is implemented by the actions declaring the requirements
+type ExpActionRequirementSpec interface {
+	// Requirements returns nil if the action has no requirements
+	Requirements() *ActionRequirements
+}
+
+// RequirementsOf returns the requirements of the action, returns nil if the action doesn't implement ExpActionRequirementSpec
+func RequirementsOf(action ExpActionCommandSpec) *ActionRequirements {
+	if requirementSpec, ok := action.(ExpActionRequirementSpec); ok {
+		return requirementSpec.Requirements()
+	}
+	return nil
+}
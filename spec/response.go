@@ -34,6 +34,7 @@ var (
 	OK                                = CodeType{200, "success"}
 	ReturnOKDirectly                  = CodeType{201, "return ok directly"}
 	Forbidden                         = CodeType{43000, "Forbidden: must be root"}
+	SafetyPolicyViolated              = CodeType{43001, "forbidden by the safety policy: %s"}
 	ActionNotSupport                  = CodeType{44000, "`%s`: action not supported"}
 	ParameterLess                     = CodeType{45000, "less parameter: `%s`"}
 	ParameterIllegal                  = CodeType{46000, "illegal `%s` parameter value: `%s`. %v"}
@@ -51,6 +52,7 @@ var (
 	ParameterInvalidDockContainerName = CodeType{47011, "invalid parameter `%s`, can not find container by name"}
 	ParameterInvalidTooManyProcess    = CodeType{47012, "invalid parameter process, too many `%s` processes found"}
 	DeployChaosBladeFailed            = CodeType{47013, "deploy chaosblade to `%s` failed, err: %v"}
+	ParameterConstraintViolated       = CodeType{47014, "invalid parameters `%s`, %s"}
 	ParameterRequestFailed            = CodeType{48000, "get request parameter failed"}
 	CommandIllegal                    = CodeType{49000, "illegal command, err: %v"}
 	CommandNetworkExist               = CodeType{49001, "network tc exec failed! RTNETLINK answers: File exists"}
@@ -76,8 +78,12 @@ var (
 	CommandTarNotFound                = CodeType{52018, "`tar`: command not found"}
 	CommandSystemctlNotFound          = CodeType{52019, "`systemctl`: command not found"}
 	CommandNohupNotFound              = CodeType{52020, "`nohup`: command not found"}
+	RequirementsNotMet                = CodeType{52100, "requirements not met: %s"}
 	ChaosbladeServerStarted           = CodeType{53000, "the chaosblade has been started. If you want to stop it, you can execute blade server stop command"}
 	UnexpectedStatus                  = CodeType{54000, "unexpected status, expected status: `%s`, but the real status: `%s`, please wait!"}
+	StatusIllegal                     = CodeType{54001, "illegal experiment status: `%s`"}
+	StatusTransitionIllegal           = CodeType{54002, "illegal experiment status transition from `%s` to `%s`"}
+	PrepareNotReady                   = CodeType{54003, "`%s` target requires the `%s` prepare in effect, please execute prepare command firstly"}
 	DockerExecNotFound                = CodeType{55000, "`%s`: the docker exec not found"}
 	DockerImagePullFailed             = CodeType{55001, "pull image failed, err: %v"}
 	CriExecNotFound                   = CodeType{55002, "`%s`, the cri exc not found"}
@@ -110,6 +116,8 @@ var (
 	CreateContainerFailed             = CodeType{63066, "create container failed, err: %v"}
 	ContainerExecFailed               = CodeType{63067, "`%s`: container exec failed, err: %v"}
 	OsExecutorNotFound                = CodeType{63070, "`%s`: os executor not found"}
+	RecoveryExecutorNotFound          = CodeType{63071, "`%s %s`: the executor to destroy the experiment not found"}
+	PanicRecovered                    = CodeType{63080, "`%s`: panic recovered, err: %v"}
 	ChaosfsClientFailed               = CodeType{64000, "init chaosfs client failed in pod %v, err: %v"}
 	ChaosfsInjectFailed               = CodeType{64001, "inject io exception in pod %s failed, request %v, err: %v"}
 	ChaosfsRecoverFailed              = CodeType{64002, "recover io exception failed in pod  %v, err: %v"}
@@ -118,6 +126,7 @@ var (
 	SystemdNotFound                   = CodeType{66001, "`%s`: systemd not found, err: %v"}
 	DatabaseError                     = CodeType{67001, "`%s`: failed to execute, err: %v"}
 	DataNotFound                      = CodeType{67002, "`%s` record not found, if it's k8s experiment, please add --target k8s flag to retry"}
+	DataExists                        = CodeType{67003, "`%s` record already exists"}
 )
 
 func (c CodeType) Sprintf(values ...interface{}) string {
@@ -129,6 +138,8 @@ type Response struct {
 	Success bool        `json:"success"`
 	Err     string      `json:"error,omitempty"`
 	Result  interface{} `json:"result,omitempty"`
+	// Warnings are the messages which don't fail the experiment, for example the deprecated flags used
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 func (response *Response) Error() string {
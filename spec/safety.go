@@ -0,0 +1,274 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The flags read by ResolveTargets, the values are split by comma
+var (
+	SafetyPidFlags        = []string{"pid"}
+	SafetyProcessFlags    = []string{"process"}
+	SafetyProcessCmdFlags = []string{"process-cmd"}
+	SafetyPortFlags       = []string{"port", "local-port", "remote-port"}
+	SafetyPathFlags       = []string{"path", "file", "directory", "mount-point"}
+
+	// SafetyDefaultPaths are the paths used by the executors if the path flags are absent, keyed by "target action"
+	SafetyDefaultPaths = map[string]string{"disk fill": "/", "disk burn": "/"}
+)
+
+// SafetyPolicy limits the blast radius of the experiments, for example:
+//
+//	deniedActions: [process kill, disk fill]
+//	deniedFlagValues:
+//	  process: [sshd, kubelet]
+//	maxProcesses: 10
+//	protectedUsers: [root]
+//	protectedPaths: [/, /etc]
+type SafetyPolicy struct {
+	// DeniedActions are the targets or the actions rejected, for example "process" or "process kill"
+	DeniedActions []string `yaml:"deniedActions,flow,omitempty" json:"deniedActions,omitempty"`
+	// DeniedFlagValues are the flag values rejected, keyed by the flag name
+	DeniedFlagValues map[string][]string `yaml:"deniedFlagValues,omitempty" json:"deniedFlagValues,omitempty"`
+	// ProtectedPids are the process ids which can't be affected
+	ProtectedPids []string `yaml:"protectedPids,flow,omitempty" json:"protectedPids,omitempty"`
+	// ProtectedPorts are the ports which can't be affected
+	ProtectedPorts []string `yaml:"protectedPorts,flow,omitempty" json:"protectedPorts,omitempty"`
+	// ProtectedUsers are the users whose processes can't be affected
+	ProtectedUsers []string `yaml:"protectedUsers,flow,omitempty" json:"protectedUsers,omitempty"`
+	// ProtectedPaths are the paths which can't be affected, including the paths under them.
+	// The root directory protects itself only, otherwise every path is protected.
+	ProtectedPaths []string `yaml:"protectedPaths,flow,omitempty" json:"protectedPaths,omitempty"`
+	// MaxProcesses is the max number of the processes affected, it's unlimited if it's not positive
+	MaxProcesses int `yaml:"maxProcesses,omitempty" json:"maxProcesses,omitempty"`
+}
+
+// DefaultMaxProcesses is the max number of the processes affected by the default policy
+const DefaultMaxProcesses = 50
+
+// DefaultSafetyPolicy protects the init process, the root directory and the system directories,
+// and limits the processes affected to DefaultMaxProcesses.
+// The disk fill and disk burn experiments without the path flags are rejected by it on purpose,
+// because they affect the root directory by SafetyDefaultPaths, the path flag must be given explicitly.
+func DefaultSafetyPolicy() *SafetyPolicy {
+	return &SafetyPolicy{
+		MaxProcesses:   DefaultMaxProcesses,
+		ProtectedPids:  []string{"1"},
+		ProtectedPaths: []string{"/", "/bin", "/boot", "/dev", "/etc", "/lib", "/lib64", "/proc", "/sbin", "/sys", "/usr"},
+	}
+}
+
+// SafetyTargets are the targets of the experiment resolved on the host
+type SafetyTargets struct {
+	// Pids are the process ids matched
+	Pids []string `json:"pids,omitempty"`
+	// Users are the owners of the processes keyed by the process id
+	Users map[string]string `json:"users,omitempty"`
+	Ports []string          `json:"ports,omitempty"`
+	Paths []string          `json:"paths,omitempty"`
+}
+
+// SafetyViolation is the rule of the policy violated by the experiment
+type SafetyViolation struct {
+	Code    CodeType `json:"-"`
+	Rule    string   `json:"rule"`
+	Message string   `json:"message"`
+}
+
+func (v SafetyViolation) String() string {
+	return v.Message
+}
+
+// Evaluate returns the rules violated by the experiment and the targets, the targets can be nil
+func (p *SafetyPolicy) Evaluate(model *ExpModel, targets *SafetyTargets) []SafetyViolation {
+	violations := make([]SafetyViolation, 0)
+	violate := func(rule, format string, args ...interface{}) {
+		message := fmt.Sprintf(format, args...)
+		violations = append(violations, SafetyViolation{
+			Code:    SafetyPolicyViolated,
+			Rule:    rule,
+			Message: fmt.Sprintf(SafetyPolicyViolated.Msg, message),
+		})
+	}
+	for _, denied := range p.DeniedActions {
+		fields := strings.Fields(denied)
+		if len(fields) == 0 || fields[0] != model.Target || (len(fields) > 1 && fields[1] != model.ActionName) {
+			continue
+		}
+		violate("deniedActions", "`%s` is denied", denied)
+	}
+	// the flags are sorted for the stable order of the violations
+	for _, flag := range slices.Sorted(maps.Keys(p.DeniedFlagValues)) {
+		for _, value := range splitValues(model.ActionFlags[flag]) {
+			if slices.Contains(p.DeniedFlagValues[flag], value) {
+				violate("deniedFlagValues", "`%s` value `%s` is denied", flag, value)
+			}
+		}
+	}
+	if targets == nil {
+		return violations
+	}
+	for _, pid := range targets.Pids {
+		if slices.Contains(p.ProtectedPids, pid) {
+			violate("protectedPids", "process %s is protected", pid)
+		}
+		if user := targets.Users[pid]; user != "" && slices.Contains(p.ProtectedUsers, user) {
+			violate("protectedUsers", "process %s of user %s is protected", pid, user)
+		}
+	}
+	if p.MaxProcesses > 0 && len(targets.Pids) > p.MaxProcesses {
+		process := model.ActionFlags["process"]
+		if process == "" {
+			process = strings.Join(targets.Pids, ",")
+		}
+		violations = append(violations, SafetyViolation{
+			Code: ParameterInvalidTooManyProcess,
+			Rule: "maxProcesses",
+			Message: fmt.Sprintf(ParameterInvalidTooManyProcess.Msg, process) +
+				fmt.Sprintf(", %d matched but at most %d allowed", len(targets.Pids), p.MaxProcesses),
+		})
+	}
+	for _, port := range targets.Ports {
+		if slices.Contains(p.ProtectedPorts, port) {
+			violate("protectedPorts", "port %s is protected", port)
+		}
+	}
+	for _, target := range targets.Paths {
+		if pattern, ok := p.protectedPath(target); ok {
+			violate("protectedPaths", "path %s is protected by %s", target, pattern)
+		}
+	}
+	return violations
+}
+
+func (p *SafetyPolicy) protectedPath(target string) (string, bool) {
+	target = path.Clean(target)
+	for _, protected := range p.ProtectedPaths {
+		cleaned := path.Clean(protected)
+		if target == cleaned || cleaned != "/" && strings.HasPrefix(target, cleaned+"/") {
+			return protected, true
+		}
+	}
+	return "", false
+}
+
+// Check returns nil,true if the experiment doesn't violate the policy. Otherwise, the response code is
+// the code of the first violation, and the response contains all violation messages and the violations as the result.
+func (p *SafetyPolicy) Check(model *ExpModel, targets *SafetyTargets) (*Response, bool) {
+	violations := p.Evaluate(model, targets)
+	if len(violations) == 0 {
+		return nil, true
+	}
+	messages := make([]string, len(violations))
+	for idx, violation := range violations {
+		messages[idx] = violation.Message
+	}
+	return ResponseFail(violations[0].Code.Code, strings.Join(messages, "; "), violations), false
+}
+
+// ResolveTargets resolves the processes, the ports and the paths of the experiment by the flags.
+// The path in SafetyDefaultPaths is used if the path flags are absent.
+// The process owners are resolved only if resolveUsers is true.
+func ResolveTargets(ctx context.Context, channel Channel, model *ExpModel, resolveUsers bool) (*SafetyTargets, error) {
+	targets := &SafetyTargets{
+		Pids:  make([]string, 0),
+		Users: make(map[string]string),
+		Ports: flagValues(model, SafetyPortFlags),
+		Paths: flagValues(model, SafetyPathFlags),
+	}
+	if defaultPath, ok := SafetyDefaultPaths[model.Target+" "+model.ActionName]; ok && len(targets.Paths) == 0 {
+		targets.Paths = append(targets.Paths, defaultPath)
+	}
+	targets.Pids = append(targets.Pids, flagValues(model, SafetyPidFlags)...)
+	for _, process := range flagValues(model, SafetyProcessFlags) {
+		pids, err := channel.GetPidsByProcessName(process, ctx)
+		if err != nil {
+			return nil, err
+		}
+		targets.Pids = append(targets.Pids, pids...)
+	}
+	for _, processCmd := range flagValues(model, SafetyProcessCmdFlags) {
+		pids, err := channel.GetPidsByProcessCmdName(processCmd, ctx)
+		if err != nil {
+			return nil, err
+		}
+		targets.Pids = append(targets.Pids, pids...)
+	}
+	slices.Sort(targets.Pids)
+	targets.Pids = slices.Compact(targets.Pids)
+	if !resolveUsers {
+		return targets, nil
+	}
+	for _, pid := range targets.Pids {
+		user, err := channel.GetPidUser(pid)
+		if err != nil {
+			logrus.WithField(Uid, ctx.Value(Uid)).Warnf("get the user of process %s failed, %v", pid, err)
+			continue
+		}
+		targets.Users[pid] = user
+	}
+	return targets, nil
+}
+
+// SafetyMiddleware resolves the targets through the channel and rejects the experiment violating the policy.
+// The experiment is rejected too if the targets can't be resolved, because the policy can't be checked.
+// The destroy command is not checked, so the experiment can always be cleaned up.
+func SafetyMiddleware(policy *SafetyPolicy, channel Channel) Middleware {
+	return func(next Executor) Executor {
+		return WrapExecutor(next, func(uid string, ctx context.Context, model *ExpModel) *Response {
+			if _, ok := IsDestroy(ctx); ok {
+				return next.Exec(uid, ctx, model)
+			}
+			targets, err := ResolveTargets(ctx, channel, model, len(policy.ProtectedUsers) > 0)
+			if err != nil {
+				logrus.WithField(Uid, uid).Warnf("resolve the targets failed, %v", err)
+				return ResponseFailWithFlags(SafetyPolicyViolated, fmt.Sprintf("the targets can't be resolved, %v", err))
+			}
+			if response, ok := policy.Check(model, targets); !ok {
+				logrus.WithField(Uid, uid).Warnf("%s %s experiment is rejected, %s", model.Target, model.ActionName, response.Err)
+				return response
+			}
+			return next.Exec(uid, ctx, model)
+		})
+	}
+}
+
+func flagValues(model *ExpModel, flags []string) []string {
+	values := make([]string, 0)
+	for _, flag := range flags {
+		values = append(values, splitValues(model.ActionFlags[flag])...)
+	}
+	return values
+}
+
+func splitValues(value string) []string {
+	values := make([]string, 0)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
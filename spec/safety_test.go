@@ -0,0 +1,190 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// processChannel resolves the process keyword to the pids, the other methods are not implemented
+type processChannel struct {
+	Channel
+	pids  map[string][]string
+	users map[string]string
+}
+
+func (c *processChannel) GetPidsByProcessName(processName string, ctx context.Context) ([]string, error) {
+	if processName == "broken" {
+		return nil, errors.New("ps failed")
+	}
+	return c.pids[processName], nil
+}
+
+func (c *processChannel) GetPidUser(pid string) (string, error) {
+	return c.users[pid], nil
+}
+
+func TestSafetyPolicyEvaluate(t *testing.T) {
+	policy := DefaultSafetyPolicy()
+	policy.DeniedActions = []string{"process kill"}
+	policy.DeniedFlagValues = map[string][]string{"process": {"sshd"}}
+	policy.ProtectedPorts = []string{"22"}
+	policy.ProtectedUsers = []string{"root"}
+	policy.ProtectedPaths = append(policy.ProtectedPaths, "/var/lib")
+	policy.MaxProcesses = 2
+
+	tests := []struct {
+		name      string
+		model     *ExpModel
+		targets   *SafetyTargets
+		wantRules []string
+	}{
+		{
+			name:    "allowed",
+			model:   &ExpModel{Target: "process", ActionName: "stop", ActionFlags: map[string]string{"process": "nginx"}},
+			targets: &SafetyTargets{Pids: []string{"100"}, Users: map[string]string{"100": "nginx"}},
+		},
+		{
+			name:      "denied action and flag value",
+			model:     &ExpModel{Target: "process", ActionName: "kill", ActionFlags: map[string]string{"process": "nginx,sshd"}},
+			wantRules: []string{"deniedActions", "deniedFlagValues"},
+		},
+		{
+			name:  "protected processes",
+			model: &ExpModel{Target: "process", ActionName: "stop", ActionFlags: map[string]string{"process": "java"}},
+			targets: &SafetyTargets{
+				Pids:  []string{"1", "100", "101"},
+				Users: map[string]string{"1": "root", "100": "admin", "101": "admin"},
+			},
+			wantRules: []string{"protectedPids", "protectedUsers", "maxProcesses"},
+		},
+		{
+			name:      "protected ports and paths",
+			model:     &ExpModel{Target: "disk", ActionName: "fill"},
+			targets:   &SafetyTargets{Ports: []string{"22", "8080"}, Paths: []string{"/", "/home/admin", "/var/lib/docker/", "/etc/ssh/sshd_config", "/etcd"}},
+			wantRules: []string{"protectedPorts", "protectedPaths", "protectedPaths", "protectedPaths"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := policy.Evaluate(tt.model, tt.targets)
+			rules := make([]string, len(violations))
+			for idx, violation := range violations {
+				rules[idx] = violation.Rule
+			}
+			if len(rules) != len(tt.wantRules) {
+				t.Fatalf("Evaluate() = %v, want %v", violations, tt.wantRules)
+			}
+			for idx := range rules {
+				if rules[idx] != tt.wantRules[idx] {
+					t.Errorf("Evaluate() = %v, want %v", rules, tt.wantRules)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestSafetyPolicyDeniedFlagValuesOrder(t *testing.T) {
+	policy := &SafetyPolicy{DeniedFlagValues: map[string][]string{"signal": {"9"}, "process": {"sshd"}, "pid": {"1"}}}
+	model := &ExpModel{Target: "process", ActionName: "kill", ActionFlags: map[string]string{"signal": "9", "process": "sshd", "pid": "1"}}
+	want := []string{"pid", "process", "signal"}
+	for i := 0; i < 10; i++ {
+		violations := policy.Evaluate(model, nil)
+		if len(violations) != len(want) {
+			t.Fatalf("Evaluate() = %v, want the violations of %v", violations, want)
+		}
+		for idx, flag := range want {
+			if !strings.Contains(violations[idx].Message, "`"+flag+"`") {
+				t.Fatalf("Evaluate() = %v, want the violations of %v in order", violations, want)
+			}
+		}
+	}
+}
+
+func TestSafetyMiddleware(t *testing.T) {
+	trace := make([]string, 0)
+	channel := &processChannel{
+		pids:  map[string][]string{"java": {"100", "101", "102"}, "nginx": {"200"}},
+		users: map[string]string{"100": "admin", "101": "admin", "102": "admin", "200": "nginx"},
+	}
+	policy := &SafetyPolicy{MaxProcesses: 2, ProtectedUsers: []string{"root"}}
+	executor := SafetyMiddleware(policy, channel)(&fakeExecutor{exec: traceExec(&trace)})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		process  string
+		wantCode int32
+	}{
+		{name: "allowed", ctx: context.Background(), process: "nginx", wantCode: OK.Code},
+		{name: "too many processes", ctx: context.Background(), process: "java", wantCode: ParameterInvalidTooManyProcess.Code},
+		{name: "targets not resolved", ctx: context.Background(), process: "broken", wantCode: SafetyPolicyViolated.Code},
+		{name: "destroy is not checked", ctx: SetDestroyFlag(context.Background(), "e1"), process: "java", wantCode: OK.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &ExpModel{Target: "process", ActionName: "stop", ActionFlags: map[string]string{"process": tt.process}}
+			if response := executor.Exec("e1", tt.ctx, model); response.Code != tt.wantCode {
+				t.Errorf("Exec() = %v, want code %d", response, tt.wantCode)
+			}
+		})
+	}
+	if len(trace) != 2 {
+		t.Errorf("executor invoked %d times, want 2", len(trace))
+	}
+}
+
+func TestResolveTargets(t *testing.T) {
+	channel := &processChannel{pids: map[string][]string{"java": {"101", "100"}}}
+	tests := []struct {
+		name  string
+		model *ExpModel
+		want  *SafetyTargets
+	}{
+		{
+			name:  "flags",
+			model: &ExpModel{Target: "process", ActionName: "kill", ActionFlags: map[string]string{"process": "java", "pid": "100,102", "local-port": "8080"}},
+			want:  &SafetyTargets{Pids: []string{"100", "101", "102"}, Users: map[string]string{}, Ports: []string{"8080"}, Paths: []string{}},
+		},
+		{
+			name:  "default path of disk fill",
+			model: &ExpModel{Target: "disk", ActionName: "fill", ActionFlags: map[string]string{"size": "1024"}},
+			want:  &SafetyTargets{Pids: []string{}, Users: map[string]string{}, Ports: []string{}, Paths: []string{"/"}},
+		},
+		{
+			name:  "path of disk fill",
+			model: &ExpModel{Target: "disk", ActionName: "fill", ActionFlags: map[string]string{"path": "/home"}},
+			want:  &SafetyTargets{Pids: []string{}, Users: map[string]string{}, Ports: []string{}, Paths: []string{"/home"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveTargets(context.Background(), channel, tt.model, false)
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveTargets() = %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+	if violations := DefaultSafetyPolicy().Evaluate(tests[1].model, tests[1].want); len(violations) != 1 {
+		t.Errorf("Evaluate() = %v, want the root directory protected", violations)
+	}
+}
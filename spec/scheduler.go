@@ -0,0 +1,287 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ParseTimeout parses the timeout flag value, the value is seconds, or the duration format like 10m
+func ParseTimeout(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("the timeout must be positive")
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("the timeout must be positive")
+	}
+	return timeout, nil
+}
+
+// ExecutorResolver returns the executor which is able to destroy the experiment
+type ExecutorResolver func(model *ExpModel) Executor
+
+// RecoveryReporter receives the auto-recovery result of the experiment
+type RecoveryReporter func(uid string, response *Response)
+
+// ExperimentScheduler destroys the experiments automatically when the timeout flag elapses.
+// The deadline is calculated by the record create time, so the timers can be restored from
+// the ExperimentStore by Recover after the process restarts.
+type ExperimentScheduler struct {
+	store    ExperimentStore
+	resolver ExecutorResolver
+	reporter RecoveryReporter
+
+	lock   sync.Mutex
+	timers map[string]*time.Timer
+	closed bool
+}
+
+func NewExperimentScheduler(store ExperimentStore, resolver ExecutorResolver) *ExperimentScheduler {
+	return &ExperimentScheduler{
+		store:    store,
+		resolver: resolver,
+		timers:   make(map[string]*time.Timer),
+	}
+}
+
+// SetReporter sets the reporter invoked after the experiment is destroyed by the scheduler
+func (s *ExperimentScheduler) SetReporter(reporter RecoveryReporter) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.reporter = reporter
+}
+
+// Schedule arms the timer of the experiment which is created successfully.
+// It returns false if the experiment has no timeout flag or the record status is not StatusSuccess.
+func (s *ExperimentScheduler) Schedule(uid string) (bool, error) {
+	record, err := s.store.Get(uid)
+	if err != nil {
+		return false, err
+	}
+	return s.schedule(record)
+}
+
+func (s *ExperimentScheduler) schedule(record *ExperimentRecord) (bool, error) {
+	if record.Status != StatusSuccess || record.Model == nil {
+		return false, nil
+	}
+	value := record.Model.ActionFlags[TimeoutFlag]
+	if value == "" {
+		return false, nil
+	}
+	timeout, err := ParseTimeout(value)
+	if err != nil {
+		return false, ResponseFailWithFlags(ParameterIllegal, TimeoutFlag, value, err)
+	}
+	if timeout == 0 {
+		return false, nil
+	}
+	delay := time.Until(record.CreateTime.Add(timeout))
+	if delay < 0 {
+		delay = 0
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return false, nil
+	}
+	if timer, ok := s.timers[record.Uid]; ok {
+		timer.Stop()
+	}
+	uid := record.Uid
+	s.timers[uid] = time.AfterFunc(delay, func() {
+		s.fire(uid)
+	})
+	logrus.WithField("uid", uid).Infof("the experiment will be destroyed automatically after %s", delay)
+	return true, nil
+}
+
+// Recover arms the timers of all experiments in StatusSuccess, the experiments expired are destroyed immediately.
+// It returns the number of the timers armed.
+func (s *ExperimentScheduler) Recover() (int, error) {
+	records, err := s.store.Query(ExperimentQuery{Status: StatusSuccess})
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, record := range records {
+		armed, err := s.schedule(record)
+		if err != nil {
+			logrus.WithField("uid", record.Uid).Warnf("restore the auto-recovery timer failed, %v", err)
+			continue
+		}
+		if armed {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Cancel stops the timer of the experiment, it returns false if the timer does not exist
+func (s *ExperimentScheduler) Cancel(uid string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	timer, ok := s.timers[uid]
+	if !ok {
+		return false
+	}
+	delete(s.timers, uid)
+	return timer.Stop()
+}
+
+// Shutdown stops all timers, the experiments are still recorded in the store and can be restored by Recover
+func (s *ExperimentScheduler) Shutdown() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for uid, timer := range s.timers {
+		timer.Stop()
+		delete(s.timers, uid)
+	}
+	s.closed = true
+	return nil
+}
+
+func (s *ExperimentScheduler) fire(uid string) {
+	s.lock.Lock()
+	delete(s.timers, uid)
+	reporter := s.reporter
+	s.lock.Unlock()
+
+	response := s.destroy(uid)
+	logrus.WithField("uid", uid).Infof("auto-recovery result: %s", response.Print())
+	if reporter != nil {
+		reporter(uid, response)
+	}
+}
+
+func (s *ExperimentScheduler) destroy(uid string) *Response {
+	record, err := s.store.Get(uid)
+	if err != nil {
+		return StoreErrorToResponse("query", err)
+	}
+	// the experiment may be destroyed manually
+	if record.Status != StatusSuccess {
+		return ResponseFailWithFlags(UnexpectedStatus, StatusSuccess, record.Status)
+	}
+	if err := s.store.UpdateStatus(uid, StatusDestroying, ""); err != nil {
+		return StoreErrorToResponse("update", err)
+	}
+	var executor Executor
+	if s.resolver != nil {
+		executor = s.resolver(record.Model)
+	}
+	if executor == nil {
+		response := ResponseFailWithFlags(RecoveryExecutorNotFound, record.Model.Target, record.Model.ActionName)
+		s.updateStatus(uid, StatusError, response.Err)
+		return response
+	}
+	ctx := SetDestroyFlag(context.WithValue(context.Background(), Uid, uid), uid)
+	// the timer goroutine must not crash the process
+	response := SafeExec(uid, ctx, executor, record.Model)
+	if response.Success {
+		s.updateStatus(uid, StatusDestroyed, "")
+	} else {
+		s.updateStatus(uid, StatusError, response.Err)
+	}
+	return response
+}
+
+// ScheduleMiddleware records the experiment created with the timeout flag in the store of the scheduler,
+// and arms the timer after the experiment is created successfully, so it's destroyed automatically.
+// The record is created with StatusCreated if it does not exist, and the status is updated by the response.
+// The timer is cancelled and the record is marked as destroyed after the experiment is destroyed,
+// so Recover doesn't destroy it again. The dry run invocation is not scheduled.
+func ScheduleMiddleware(scheduler *ExperimentScheduler) Middleware {
+	return func(next Executor) Executor {
+		return WrapExecutor(next, func(uid string, ctx context.Context, model *ExpModel) *Response {
+			if suid, ok := IsDestroy(ctx); ok {
+				response := next.Exec(uid, ctx, model)
+				scheduler.destroyed(suid, response)
+				return response
+			}
+			if uid == "" || model == nil || model.ActionFlags[TimeoutFlag] == "" || IsDryRun(ctx) {
+				return next.Exec(uid, ctx, model)
+			}
+			value := model.ActionFlags[TimeoutFlag]
+			if _, err := ParseTimeout(value); err != nil {
+				return ResponseFailWithFlags(ParameterIllegal, TimeoutFlag, value, err)
+			}
+			if _, err := scheduler.store.Get(uid); err != nil {
+				if err := scheduler.store.Create(NewExperimentRecord(uid, model)); err != nil {
+					return StoreErrorToResponse("create", err)
+				}
+			}
+			response := next.Exec(uid, ctx, model)
+			if response == nil || !response.Success {
+				errMsg := "the executor returns nil response"
+				if response != nil {
+					errMsg = response.Err
+				}
+				scheduler.updateStatus(uid, StatusError, errMsg)
+				return response
+			}
+			if record, err := scheduler.store.Get(uid); err == nil && record.Status != StatusSuccess {
+				scheduler.updateStatus(uid, StatusSuccess, "")
+			}
+			if _, err := scheduler.Schedule(uid); err != nil {
+				logrus.WithField(Uid, uid).Warnf("schedule the auto-recovery failed, %v", err)
+				response.Warnings = append(response.Warnings, fmt.Sprintf("schedule the auto-recovery failed, %v", err))
+			}
+			return response
+		})
+	}
+}
+
+// destroyed cancels the timer and updates the record status by the response of the manual destroy,
+// the record which is not scheduled or already in a terminal status is kept
+func (s *ExperimentScheduler) destroyed(uid string, response *Response) {
+	record, err := s.store.Get(uid)
+	if err != nil || (record.Status != StatusSuccess && record.Status != StatusError) {
+		return
+	}
+	if response == nil || !response.Success {
+		errMsg := "the executor returns nil response"
+		if response != nil {
+			errMsg = response.Err
+		}
+		s.updateStatus(uid, StatusError, errMsg)
+		return
+	}
+	s.Cancel(uid)
+	s.updateStatus(uid, StatusDestroyed, "")
+}
+
+func (s *ExperimentScheduler) updateStatus(uid, status, errMsg string) {
+	if err := s.store.UpdateStatus(uid, status, errMsg); err != nil {
+		logrus.WithField("uid", uid).Warnf("update the experiment status to %s failed, %v", status, err)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// recordDestroy sends the uid of the experiment destroyed to the channel
+func recordDestroy(destroyed chan<- string) func(uid string, ctx context.Context, model *ExpModel) *Response {
+	return func(uid string, ctx context.Context, model *ExpModel) *Response {
+		if suid, ok := IsDestroy(ctx); ok {
+			destroyed <- suid
+		}
+		return Success()
+	}
+}
+
+func TestExperimentScheduler_Recover(t *testing.T) {
+	store := NewMemoryExperimentStore()
+	expired := NewExperimentRecord("expired", &ExpModel{
+		Target: "cpu", ActionName: "fullload", ActionFlags: map[string]string{TimeoutFlag: "1"},
+	})
+	expired.CreateTime = time.Now().Add(-time.Minute)
+	pending := NewExperimentRecord("pending", &ExpModel{
+		Target: "cpu", ActionName: "fullload", ActionFlags: map[string]string{TimeoutFlag: "1h"},
+	})
+	noTimeout := NewExperimentRecord("noTimeout", &ExpModel{Target: "cpu", ActionName: "fullload"})
+	for _, record := range []*ExperimentRecord{expired, pending, noTimeout} {
+		store.Create(record)
+		store.UpdateStatus(record.Uid, StatusSuccess, "")
+	}
+
+	destroyed := make(chan string, 1)
+	executor := &fakeExecutor{exec: recordDestroy(destroyed)}
+	scheduler := NewExperimentScheduler(store, func(model *ExpModel) Executor {
+		return executor
+	})
+	reported := make(chan *Response, 1)
+	scheduler.SetReporter(func(uid string, response *Response) {
+		reported <- response
+	})
+	defer scheduler.Shutdown()
+
+	count, err := scheduler.Recover()
+	if err != nil {
+		t.Fatalf("Recover() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Recover() armed %d timers, want 2", count)
+	}
+	select {
+	case uid := <-destroyed:
+		if uid != "expired" {
+			t.Errorf("destroyed uid = %s, want expired", uid)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the expired experiment is not destroyed")
+	}
+	if response := <-reported; !response.Success {
+		t.Errorf("reported response = %s, want success", response.Print())
+	}
+	record, _ := store.Get("expired")
+	if record.Status != StatusDestroyed {
+		t.Errorf("record status = %s, want %s", record.Status, StatusDestroyed)
+	}
+	if !scheduler.Cancel("pending") {
+		t.Errorf("Cancel() pending timer returns false")
+	}
+}
+
+func TestScheduleMiddleware(t *testing.T) {
+	store := NewMemoryExperimentStore()
+	destroyed := make(chan string, 1)
+	recorder := &fakeExecutor{exec: recordDestroy(destroyed)}
+	scheduler := NewExperimentScheduler(store, func(model *ExpModel) Executor {
+		return recorder
+	})
+	defer scheduler.Shutdown()
+	executor := Chain(recorder, ScheduleMiddleware(scheduler))
+
+	model := &ExpModel{Target: "cpu", ActionName: "fullload", ActionFlags: map[string]string{TimeoutFlag: "1h"}}
+	if response := executor.Exec("e1", context.Background(), model); !response.Success {
+		t.Fatalf("Exec() = %s, want success", response.Print())
+	}
+	if record, err := store.Get("e1"); err != nil || record.Status != StatusSuccess {
+		t.Fatalf("Get() = %v, %v, want the record in %s", record, err, StatusSuccess)
+	}
+	if response := executor.Exec("", SetDestroyFlag(context.Background(), "e1"), model); !response.Success {
+		t.Fatalf("Exec() destroy = %s, want success", response.Print())
+	}
+	<-destroyed
+	if scheduler.Cancel("e1") {
+		t.Errorf("Cancel() after destroy returns true, want the timer cancelled by the middleware")
+	}
+	if record, _ := store.Get("e1"); record.Status != StatusDestroyed {
+		t.Errorf("Get() status after destroy = %s, want %s", record.Status, StatusDestroyed)
+	}
+	restarted := NewExperimentScheduler(store, func(model *ExpModel) Executor {
+		return recorder
+	})
+	defer restarted.Shutdown()
+	if count, err := restarted.Recover(); err != nil || count != 0 {
+		t.Errorf("Recover() after destroy = %d, %v, want 0 timers", count, err)
+	}
+
+	illegal := &ExpModel{Target: "cpu", ActionName: "fullload", ActionFlags: map[string]string{TimeoutFlag: "abc"}}
+	if response := executor.Exec("e2", context.Background(), illegal); response.Code != ParameterIllegal.Code {
+		t.Errorf("Exec() illegal timeout code = %d, want %d", response.Code, ParameterIllegal.Code)
+	}
+	if response := executor.Exec("e3", context.Background(), &ExpModel{Target: "cpu", ActionName: "fullload"}); !response.Success {
+		t.Errorf("Exec() without timeout = %s, want success", response.Print())
+	}
+	if _, err := store.Get("e3"); err == nil {
+		t.Errorf("Get() the experiment without timeout, want not recorded")
+	}
+}
+
+func TestExperimentScheduler_ResolverNil(t *testing.T) {
+	store := NewMemoryExperimentStore()
+	record := NewExperimentRecord("e1", &ExpModel{Target: "cpu", ActionName: "fullload", ActionFlags: map[string]string{TimeoutFlag: "1"}})
+	record.CreateTime = time.Now().Add(-time.Minute)
+	store.Create(record)
+	store.UpdateStatus(record.Uid, StatusSuccess, "")
+
+	scheduler := NewExperimentScheduler(store, func(model *ExpModel) Executor {
+		return nil
+	})
+	reported := make(chan *Response, 1)
+	scheduler.SetReporter(func(uid string, response *Response) {
+		reported <- response
+	})
+	defer scheduler.Shutdown()
+	if _, err := scheduler.Recover(); err != nil {
+		t.Fatalf("Recover() error = %v", err)
+	}
+	select {
+	case response := <-reported:
+		if response.Code != RecoveryExecutorNotFound.Code {
+			t.Errorf("reported code = %d, want %d", response.Code, RecoveryExecutorNotFound.Code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the expired experiment is not reported")
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"errors"
+	"time"
+)
+
+// Experiment lifecycle status
+const (
+	// StatusCreated means the experiment record is created but nothing has been injected yet
+	StatusCreated = "Created"
+	// StatusRunning means the experiment is being injected, or a prepare step is active
+	StatusRunning = "Running"
+	// StatusSuccess means the experiment is injected successfully and is in effect
+	StatusSuccess = "Success"
+	// StatusError means the injection or the recovery failed
+	StatusError = "Error"
+	// StatusDestroying means the experiment is being recovered
+	StatusDestroying = "Destroying"
+	// StatusDestroyed means the experiment is recovered, it's a terminal status
+	StatusDestroyed = "Destroyed"
+	// StatusRevoked means the experiment or the prepare step is revoked, it's a terminal status
+	StatusRevoked = "Revoked"
+)
+
+// statusTransitions defines the allowed target statuses of each status
+var statusTransitions = map[string][]string{
+	StatusCreated:    {StatusRunning, StatusSuccess, StatusError, StatusDestroying, StatusDestroyed, StatusRevoked},
+	StatusRunning:    {StatusSuccess, StatusError, StatusDestroying, StatusDestroyed, StatusRevoked},
+	StatusSuccess:    {StatusError, StatusDestroying, StatusDestroyed, StatusRevoked},
+	StatusError:      {StatusDestroying, StatusDestroyed, StatusRevoked},
+	StatusDestroying: {StatusDestroyed, StatusError},
+	StatusDestroyed:  {},
+	StatusRevoked:    {},
+}
+
+// IsValidStatus returns true if the status is one of the experiment lifecycle status
+func IsValidStatus(status string) bool {
+	_, ok := statusTransitions[status]
+	return ok
+}
+
+// IsTerminalStatus returns true if the status can not transit to any other status
+func IsTerminalStatus(status string) bool {
+	next, ok := statusTransitions[status]
+	return ok && len(next) == 0
+}
+
+// CanTransit returns true if the experiment status is allowed to change from `from` to `to`.
+// The empty `from` means a new experiment, which can only start with StatusCreated.
+func CanTransit(from, to string) bool {
+	if from == "" {
+		return to == StatusCreated
+	}
+	for _, status := range statusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckStatusTransition returns an error if the transition from `from` to `to` is not allowed
+func CheckStatusTransition(from, to string) error {
+	if !IsValidStatus(to) {
+		return errors.New(StatusIllegal.Sprintf(to))
+	}
+	if from != "" && !IsValidStatus(from) {
+		return errors.New(StatusIllegal.Sprintf(from))
+	}
+	if !CanTransit(from, to) {
+		return errors.New(StatusTransitionIllegal.Sprintf(from, to))
+	}
+	return nil
+}
+
+// ExperimentRecord is the status record of the experiment identified by uid
+type ExperimentRecord struct {
+	// Uid is the experiment identifier
+	Uid string `json:"uid"`
+
+	// Model is the experiment data object
+	Model *ExpModel `json:"model,omitempty"`
+
+	// Status is the experiment lifecycle status
+	Status string `json:"status"`
+
+	// Error is the failure message when the status is StatusError
+	Error string `json:"error,omitempty"`
+
+	CreateTime time.Time `json:"createTime"`
+	UpdateTime time.Time `json:"updateTime"`
+}
+
+// NewExperimentRecord returns the experiment record with StatusCreated
+func NewExperimentRecord(uid string, model *ExpModel) *ExperimentRecord {
+	now := time.Now()
+	return &ExperimentRecord{
+		Uid:        uid,
+		Model:      model,
+		Status:     StatusCreated,
+		CreateTime: now,
+		UpdateTime: now,
+	}
+}
+
+// Transit changes the record status if the transition is allowed, errMsg is only kept for StatusError
+func (r *ExperimentRecord) Transit(status, errMsg string) error {
+	return transitStatus(&r.Status, &r.Error, &r.UpdateTime, status, errMsg)
+}
+
+// transitStatus changes the status, the error message and the update time of the record
+// if the transition is allowed, errMsg is only kept for StatusError
+func transitStatus(current, errField *string, updateTime *time.Time, status, errMsg string) error {
+	if err := CheckStatusTransition(*current, status); err != nil {
+		return err
+	}
+	*current = status
+	*errField = ""
+	if status == StatusError {
+		*errField = errMsg
+	}
+	*updateTime = time.Now()
+	return nil
+}
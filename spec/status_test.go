@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"testing"
+)
+
+func TestCheckStatusTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{name: "new experiment", from: "", to: StatusCreated},
+		{name: "new experiment must be created", from: "", to: StatusSuccess, wantErr: true},
+		{name: "created to success", from: StatusCreated, to: StatusSuccess},
+		{name: "success to destroying", from: StatusSuccess, to: StatusDestroying},
+		{name: "destroying to destroyed", from: StatusDestroying, to: StatusDestroyed},
+		{name: "destroyed is terminal", from: StatusDestroyed, to: StatusSuccess, wantErr: true},
+		{name: "revoked is terminal", from: StatusRevoked, to: StatusRunning, wantErr: true},
+		{name: "unknown target status", from: StatusCreated, to: "Unknown", wantErr: true},
+		{name: "unknown source status", from: "Unknown", to: StatusSuccess, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckStatusTransition(tt.from, tt.to); (err != nil) != tt.wantErr {
+				t.Errorf("CheckStatusTransition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExperimentRecord_Transit(t *testing.T) {
+	record := NewExperimentRecord("e1", &ExpModel{Target: "cpu", ActionName: "fullload"})
+	if err := record.Transit(StatusError, "inject failed"); err != nil {
+		t.Fatalf("Transit() unexpected error: %v", err)
+	}
+	if record.Error != "inject failed" {
+		t.Errorf("Transit() error message = %s, want %s", record.Error, "inject failed")
+	}
+	if err := record.Transit(StatusDestroyed, "ignored"); err != nil {
+		t.Fatalf("Transit() unexpected error: %v", err)
+	}
+	if record.Error != "" {
+		t.Errorf("Transit() error message = %s, want empty", record.Error)
+	}
+	if err := record.Transit(StatusSuccess, ""); err == nil {
+		t.Errorf("Transit() from terminal status expected error")
+	}
+}
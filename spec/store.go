@@ -0,0 +1,204 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ExperimentStore persists the experiment records, so that the chaosblade components can share the experiment history.
+// The errors returned are *Response, DataNotFound code means the record does not exist.
+type ExperimentStore interface {
+	// Create saves the new record, the record status must be StatusCreated
+	Create(record *ExperimentRecord) error
+
+	// UpdateStatus changes the record status by uid, see CheckStatusTransition for the allowed changes
+	UpdateStatus(uid, status, errMsg string) error
+
+	// Get returns the record by uid
+	Get(uid string) (*ExperimentRecord, error)
+
+	// Query returns the records matched the query, ordered by create time descending
+	Query(query ExperimentQuery) ([]*ExperimentRecord, error)
+
+	// Close releases the resources held by the store
+	Close() error
+}
+
+// ExperimentQuery is the condition of ExperimentStore.Query, the empty fields are ignored
+type ExperimentQuery struct {
+	Target string
+	Action string
+	Status string
+
+	// Since and Until limit the create time of records
+	Since time.Time
+	Until time.Time
+
+	// Limit is the max number of records returned, 0 means no limit
+	Limit int
+}
+
+// Match returns true if the record satisfies the query
+func (q ExperimentQuery) Match(record *ExperimentRecord) bool {
+	if q.Status != "" && q.Status != record.Status {
+		return false
+	}
+	if q.Target != "" && (record.Model == nil || q.Target != record.Model.Target) {
+		return false
+	}
+	if q.Action != "" && (record.Model == nil || q.Action != record.Model.ActionName) {
+		return false
+	}
+	if !q.Since.IsZero() && record.CreateTime.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && record.CreateTime.After(q.Until) {
+		return false
+	}
+	return true
+}
+
+// StoreErrorToResponse returns the error directly if it's *Response, otherwise wraps it with DatabaseError
+func StoreErrorToResponse(operation string, err error) *Response {
+	if response, ok := err.(*Response); ok {
+		return response
+	}
+	return ResponseFailWithFlags(DatabaseError, operation, err)
+}
+
+// MemoryExperimentStore keeps the records in memory, it's used for testing or as the cache of other stores
+type MemoryExperimentStore struct {
+	lock    sync.RWMutex
+	records map[string]*ExperimentRecord
+}
+
+// NewMemoryExperimentStore returns an empty in-memory experiment store
+func NewMemoryExperimentStore() *MemoryExperimentStore {
+	return &MemoryExperimentStore{
+		records: make(map[string]*ExperimentRecord),
+	}
+}
+
+func (m *MemoryExperimentStore) Create(record *ExperimentRecord) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	_, err := m.create(record)
+	return err
+}
+
+func (m *MemoryExperimentStore) create(record *ExperimentRecord) (*ExperimentRecord, error) {
+	if record == nil || record.Uid == "" {
+		return nil, ResponseFailWithFlags(ParameterLess, Uid)
+	}
+	if _, ok := m.records[record.Uid]; ok {
+		return nil, ResponseFailWithFlags(DataExists, record.Uid)
+	}
+	if record.Status != StatusCreated {
+		return nil, ResponseFailWithFlags(StatusTransitionIllegal, "", record.Status)
+	}
+	saved := copyExperimentRecord(record)
+	m.records[record.Uid] = saved
+	return saved, nil
+}
+
+func (m *MemoryExperimentStore) UpdateStatus(uid, status, errMsg string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	_, err := m.updateStatus(uid, status, errMsg)
+	return err
+}
+
+func (m *MemoryExperimentStore) updateStatus(uid, status, errMsg string) (*ExperimentRecord, error) {
+	record, ok := m.records[uid]
+	if !ok {
+		return nil, ResponseFailWithFlags(DataNotFound, uid)
+	}
+	updated := copyExperimentRecord(record)
+	if err := updated.Transit(status, errMsg); err != nil {
+		return nil, ReturnFail(StatusTransitionIllegal, err.Error())
+	}
+	m.records[uid] = updated
+	return updated, nil
+}
+
+func (m *MemoryExperimentStore) Get(uid string) (*ExperimentRecord, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	record, ok := m.records[uid]
+	if !ok {
+		return nil, ResponseFailWithFlags(DataNotFound, uid)
+	}
+	return copyExperimentRecord(record), nil
+}
+
+func (m *MemoryExperimentStore) Query(query ExperimentQuery) ([]*ExperimentRecord, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	records := make([]*ExperimentRecord, 0)
+	for _, record := range m.records {
+		if query.Match(record) {
+			records = append(records, copyExperimentRecord(record))
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreateTime.After(records[j].CreateTime)
+	})
+	if query.Limit > 0 && len(records) > query.Limit {
+		records = records[:query.Limit]
+	}
+	return records, nil
+}
+
+func (m *MemoryExperimentStore) Close() error {
+	return nil
+}
+
+// put saves the record without any check, it's used to restore the records
+func (m *MemoryExperimentStore) put(record *ExperimentRecord) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.records[record.Uid] = copyExperimentRecord(record)
+}
+
+// copyExperimentRecord returns a deep copy to prevent the stored record being modified by callers
+func copyExperimentRecord(record *ExperimentRecord) *ExperimentRecord {
+	copied := *record
+	if record.Model != nil {
+		model := *record.Model
+		model.ActionFlags = copyFlags(record.Model.ActionFlags)
+		model.ActionPrograms = slices.Clone(record.Model.ActionPrograms)
+		model.ActionCategories = slices.Clone(record.Model.ActionCategories)
+		copied.Model = &model
+	}
+	return &copied
+}
+
+// copyFlags returns a copy of the flags, nil is returned if the flags are nil
+func copyFlags(flags map[string]string) map[string]string {
+	if flags == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(flags))
+	for k, v := range flags {
+		copied[k] = v
+	}
+	return copied
+}
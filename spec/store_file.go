@@ -0,0 +1,190 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FileExperimentStore persists the records to a single file in JSON lines format.
+// Every change appends the whole record as a line, the last line of the uid wins when the file is loaded.
+type FileExperimentStore struct {
+	*MemoryExperimentStore
+	path string
+	// file is nil if it can't be reopened after compacting, it's reopened by the next change
+	file *os.File
+}
+
+// NewFileExperimentStore loads the records from the file and returns the store, the file is created if not exists
+func NewFileExperimentStore(path string) (*FileExperimentStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, ResponseFailWithFlags(DatabaseError, "open", err)
+	}
+	store := &FileExperimentStore{
+		MemoryExperimentStore: NewMemoryExperimentStore(),
+		path:                  path,
+	}
+	if err := store.load(); err != nil {
+		return nil, ResponseFailWithFlags(DatabaseError, "load", err)
+	}
+	if err := store.open(); err != nil {
+		return nil, ResponseFailWithFlags(DatabaseError, "open", err)
+	}
+	return store, nil
+}
+
+func (f *FileExperimentStore) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	f.file = file
+	return nil
+}
+
+func (f *FileExperimentStore) load() error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var record ExperimentRecord
+		if err := json.Unmarshal(line, &record); err != nil || record.Uid == "" {
+			// the last line may be broken if the process exits while writing
+			logrus.Warnf("skip the broken experiment record in %s, %s", f.path, string(line))
+			continue
+		}
+		f.put(&record)
+	}
+	return scanner.Err()
+}
+
+func (f *FileExperimentStore) Create(record *ExperimentRecord) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	saved, err := f.create(record)
+	if err != nil {
+		return err
+	}
+	if err := f.append(saved); err != nil {
+		delete(f.records, saved.Uid)
+		return ResponseFailWithFlags(DatabaseError, "insert", err)
+	}
+	return nil
+}
+
+func (f *FileExperimentStore) UpdateStatus(uid, status, errMsg string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	origin := f.records[uid]
+	updated, err := f.updateStatus(uid, status, errMsg)
+	if err != nil {
+		return err
+	}
+	if err := f.append(updated); err != nil {
+		f.records[uid] = origin
+		return ResponseFailWithFlags(DatabaseError, "update", err)
+	}
+	return nil
+}
+
+func (f *FileExperimentStore) append(record *ExperimentRecord) error {
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+	if _, err := f.file.Write(append(bytes, '\n')); err != nil {
+		return err
+	}
+	return f.file.Sync()
+}
+
+// Compact rewrites the file with the latest record of each uid to drop the history lines
+func (f *FileExperimentStore) Compact() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	records := make([]*ExperimentRecord, 0, len(f.records))
+	for _, record := range f.records {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreateTime.Before(records[j].CreateTime)
+	})
+	tmpPath := f.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return ResponseFailWithFlags(DatabaseError, "compact", err)
+	}
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			tmp.Close()
+			return ResponseFailWithFlags(DatabaseError, "compact", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return ResponseFailWithFlags(DatabaseError, "compact", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return ResponseFailWithFlags(DatabaseError, "compact", err)
+	}
+	// the file must be closed before renaming on windows
+	if f.file != nil {
+		f.file.Close()
+		f.file = nil
+	}
+	renameErr := os.Rename(tmpPath, f.path)
+	if err := f.open(); err != nil {
+		return ResponseFailWithFlags(DatabaseError, "compact", err)
+	}
+	if renameErr != nil {
+		return ResponseFailWithFlags(DatabaseError, "compact", renameErr)
+	}
+	return nil
+}
+
+func (f *FileExperimentStore) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Close()
+}
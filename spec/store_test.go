@@ -0,0 +1,151 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileExperimentStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "experiments.json")
+	store, err := NewFileExperimentStore(path)
+	if err != nil {
+		t.Fatalf("NewFileExperimentStore() error = %v", err)
+	}
+	cpu := NewExperimentRecord("e1", &ExpModel{Target: "cpu", ActionName: "fullload"})
+	cpu.CreateTime = time.Now().Add(-time.Hour)
+	mem := NewExperimentRecord("e2", &ExpModel{Target: "mem", ActionName: "load"})
+	for _, record := range []*ExperimentRecord{cpu, mem} {
+		if err := store.Create(record); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if err := store.Create(cpu); err == nil {
+		t.Errorf("Create() duplicated uid expected error")
+	}
+	if err := store.UpdateStatus("e1", StatusSuccess, ""); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	if err := store.UpdateStatus("e1", StatusCreated, ""); err == nil {
+		t.Errorf("UpdateStatus() illegal transition expected error")
+	}
+	store.Close()
+
+	store, err = NewFileExperimentStore(path)
+	if err != nil {
+		t.Fatalf("NewFileExperimentStore() reload error = %v", err)
+	}
+	defer store.Close()
+	if err := store.Compact(); err != nil {
+		t.Fatalf("Compact() error = %v", err)
+	}
+	record, err := store.Get("e1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if record.Status != StatusSuccess {
+		t.Errorf("Get() status = %s, want %s", record.Status, StatusSuccess)
+	}
+	if _, err := store.Get("unknown"); err == nil || err.(*Response).Code != DataNotFound.Code {
+		t.Errorf("Get() unknown uid error = %v, want DataNotFound", err)
+	}
+
+	tests := []struct {
+		name  string
+		query ExperimentQuery
+		want  []string
+	}{
+		{name: "all", query: ExperimentQuery{}, want: []string{"e2", "e1"}},
+		{name: "by target", query: ExperimentQuery{Target: "cpu"}, want: []string{"e1"}},
+		{name: "by status", query: ExperimentQuery{Status: StatusCreated}, want: []string{"e2"}},
+		{name: "by time range", query: ExperimentQuery{Since: time.Now().Add(-time.Minute)}, want: []string{"e2"}},
+		{name: "limit", query: ExperimentQuery{Limit: 1}, want: []string{"e2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := store.Query(tt.query)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			uids := make([]string, 0)
+			for _, record := range records {
+				uids = append(uids, record.Uid)
+			}
+			if len(uids) != len(tt.want) {
+				t.Fatalf("Query() = %v, want %v", uids, tt.want)
+			}
+			for idx := range uids {
+				if uids[idx] != tt.want[idx] {
+					t.Errorf("Query() = %v, want %v", uids, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryExperimentStoreCopy(t *testing.T) {
+	store := NewMemoryExperimentStore()
+	model := &ExpModel{
+		Target: "cpu", ActionName: "fullload", ActionFlags: map[string]string{"cpu-percent": "80"},
+		ActionPrograms: []string{"chaos_os"}, ActionCategories: []string{"system/cpu"},
+	}
+	if err := store.Create(NewExperimentRecord("e1", model)); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	model.ActionFlags["cpu-percent"] = "100"
+	model.ActionPrograms[0] = "changed"
+	record, _ := store.Get("e1")
+	record.Model.ActionCategories[0] = "changed"
+
+	saved, _ := store.Get("e1")
+	if saved.Model.ActionFlags["cpu-percent"] != "80" || saved.Model.ActionPrograms[0] != "chaos_os" ||
+		saved.Model.ActionCategories[0] != "system/cpu" {
+		t.Errorf("Get() = %+v, the saved model is changed by the caller", saved.Model)
+	}
+}
+
+func TestFileExperimentStoreReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "experiments.json")
+	store, err := NewFileExperimentStore(path)
+	if err != nil {
+		t.Fatalf("NewFileExperimentStore() error = %v", err)
+	}
+	if err := store.Create(NewExperimentRecord("e1", &ExpModel{Target: "cpu", ActionName: "fullload"})); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	// the file failed to be reopened after compacting
+	store.file.Close()
+	store.file = nil
+	if err := store.UpdateStatus("e1", StatusSuccess, ""); err != nil {
+		t.Fatalf("UpdateStatus() after the failed reopen error = %v", err)
+	}
+	if err := store.Compact(); err != nil {
+		t.Fatalf("Compact() error = %v", err)
+	}
+	store.Close()
+
+	store, err = NewFileExperimentStore(path)
+	if err != nil {
+		t.Fatalf("NewFileExperimentStore() reload error = %v", err)
+	}
+	defer store.Close()
+	if record, err := store.Get("e1"); err != nil || record.Status != StatusSuccess {
+		t.Errorf("Get() = %v, %v, want the record in %s", record, err, StatusSuccess)
+	}
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AddSubModels attaches the children to the model, the child with the same name is replaced.
+// The child names are kept in ExpSubTargets for compatibility.
+func (ecm *ExpCommandModel) AddSubModels(children ...ExpCommandModel) {
+	for _, child := range children {
+		if existing := ecm.FindSubModel(child.ExpName); existing != nil {
+			*existing = child
+			continue
+		}
+		ecm.ExpSubModels = append(ecm.ExpSubModels, child)
+		ecm.ExpSubTargets = append(ecm.ExpSubTargets, child.ExpName)
+	}
+}
+
+// FindSubModel returns the direct child by the target name, returns nil if not found
+func (ecm *ExpCommandModel) FindSubModel(name string) *ExpCommandModel {
+	for idx := range ecm.ExpSubModels {
+		if ecm.ExpSubModels[idx].ExpName == name {
+			return &ecm.ExpSubModels[idx]
+		}
+	}
+	return nil
+}
+
+// inherit returns the copy of the child with the flags, the scope and the executor inherited from the parent.
+// The child flag overrides the parent flag with the same name. The flags and the actions are copied deeply,
+// the sub models are shared with the child.
+func inherit(parent, child *ExpCommandModel) *ExpCommandModel {
+	effective := *child
+	if effective.ExpScope == "" {
+		effective.ExpScope = parent.ExpScope
+	}
+	if effective.ExpExecutor == nil {
+		effective.ExpExecutor = parent.ExpExecutor
+	}
+	flags := make([]ExpFlag, 0, len(parent.ExpFlags)+len(child.ExpFlags))
+	for _, flag := range parent.ExpFlags {
+		if findExpFlag(child.ExpFlags, flag.Name) == nil {
+			flags = append(flags, copyExpFlag(flag))
+		}
+	}
+	effective.ExpFlags = append(flags, copyExpFlags(child.ExpFlags)...)
+	effective.ExpActions = make([]ActionModel, 0, len(child.ExpActions))
+	for _, action := range child.ExpActions {
+		effective.ExpActions = append(effective.ExpActions, copyActionModel(action))
+	}
+	return &effective
+}
+
+// copyActionModel copies the action with the slices, so changing the copy doesn't change the action
+func copyActionModel(action ActionModel) ActionModel {
+	copied := action
+	copied.ActionAliases = slices.Clone(action.ActionAliases)
+	copied.ActionMatchers = copyExpFlags(action.ActionMatchers)
+	copied.ActionFlags = copyExpFlags(action.ActionFlags)
+	copied.ActionPrograms = slices.Clone(action.ActionPrograms)
+	copied.ActionCategories = slices.Clone(action.ActionCategories)
+	if action.ActionConstraints != nil {
+		copied.ActionConstraints = make([]FlagConstraint, 0, len(action.ActionConstraints))
+		for _, constraint := range action.ActionConstraints {
+			constraint.Flags = slices.Clone(constraint.Flags)
+			copied.ActionConstraints = append(copied.ActionConstraints, constraint)
+		}
+	}
+	if action.ActionDeprecated != nil {
+		deprecation := *action.ActionDeprecated
+		copied.ActionDeprecated = &deprecation
+	}
+	if action.ActionRequirements != nil {
+		requirements := *action.ActionRequirements
+		requirements.OS = slices.Clone(requirements.OS)
+		requirements.Arch = slices.Clone(requirements.Arch)
+		requirements.Capabilities = slices.Clone(requirements.Capabilities)
+		requirements.Commands = slices.Clone(requirements.Commands)
+		copied.ActionRequirements = &requirements
+	}
+	return copied
+}
+
+func copyExpFlags(flags []ExpFlag) []ExpFlag {
+	if flags == nil {
+		return nil
+	}
+	copied := make([]ExpFlag, 0, len(flags))
+	for _, flag := range flags {
+		copied = append(copied, copyExpFlag(flag))
+	}
+	return copied
+}
+
+// copyExpFlag copies the flag with the deprecation
+func copyExpFlag(flag ExpFlag) ExpFlag {
+	if flag.Deprecated != nil {
+		deprecation := *flag.Deprecated
+		flag.Deprecated = &deprecation
+	}
+	return flag
+}
+
+func findExpFlag(flags []ExpFlag, name string) *ExpFlag {
+	for idx := range flags {
+		if flags[idx].Name == name {
+			return &flags[idx]
+		}
+	}
+	return nil
+}
+
+// FindModel returns the top level model by the target name, returns nil if not found
+func (models *Models) FindModel(name string) *ExpCommandModel {
+	for idx := range models.Models {
+		if models.Models[idx].ExpName == name {
+			return &models.Models[idx]
+		}
+	}
+	return nil
+}
+
+// Lookup resolves the target path separated by blanks, for example "k8s pod network delay".
+// It returns the effective model of the deepest target with the inherited flags and scope,
+// and the action if the word after the target is the action name or alias.
+// The flags and the actions of the returned model are copied, changing them doesn't change the models.
+func (models *Models) Lookup(path string) (*ExpCommandModel, ExpActionCommandSpec, error) {
+	words := strings.Fields(path)
+	if len(words) == 0 {
+		return nil, nil, fmt.Errorf("the target path is empty")
+	}
+	current := models.FindModel(words[0])
+	if current == nil {
+		return nil, nil, fmt.Errorf("the %s target not found", words[0])
+	}
+	effective := inherit(&ExpCommandModel{}, current)
+	for idx := 1; idx < len(words); idx++ {
+		if child := current.FindSubModel(words[idx]); child != nil {
+			current = child
+			effective = inherit(effective, child)
+			continue
+		}
+		action := FindAction(effective, words[idx])
+		if action == nil {
+			return nil, nil, fmt.Errorf("the %s target or action not found under %s", words[idx], strings.Join(words[:idx], " "))
+		}
+		if idx != len(words)-1 {
+			return nil, nil, fmt.Errorf("unexpected words after the %s action: %s", words[idx], strings.Join(words[idx+1:], " "))
+		}
+		return effective, action, nil
+	}
+	return effective, nil, nil
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newK8sModels() *Models {
+	network := ExpCommandModel{
+		ExpName:    "network",
+		ExpActions: []ActionModel{{ActionName: "delay", ActionAliases: []string{"d"}}},
+		ExpFlags:   []ExpFlag{{Name: "namespace", Required: true}},
+	}
+	pod := ExpCommandModel{
+		ExpName:    "pod",
+		ExpActions: []ActionModel{{ActionName: "delete"}},
+		ExpFlags:   []ExpFlag{{Name: "namespace"}, {Name: "names"}},
+	}
+	pod.AddSubModels(network)
+	k8s := ExpCommandModel{
+		ExpName:  "k8s",
+		ExpScope: "kubernetes",
+		ExpFlags: []ExpFlag{{Name: "kubeconfig"}},
+	}
+	k8s.AddSubModels(pod, ExpCommandModel{ExpName: "node"})
+	return &Models{Models: []ExpCommandModel{k8s, {ExpName: "cpu"}}}
+}
+
+func TestModelsLookup(t *testing.T) {
+	models := newK8sModels()
+	tests := []struct {
+		path       string
+		wantTarget string
+		wantAction string
+		wantFlags  []string
+		wantErr    bool
+	}{
+		{path: "k8s pod network d", wantTarget: "network", wantAction: "delay", wantFlags: []string{"kubeconfig", "names", "namespace"}},
+		{path: "k8s pod", wantTarget: "pod", wantFlags: []string{"kubeconfig", "namespace", "names"}},
+		{path: "k8s pod delete", wantTarget: "pod", wantAction: "delete", wantFlags: []string{"kubeconfig", "namespace", "names"}},
+		{path: "k8s node", wantTarget: "node", wantFlags: []string{"kubeconfig"}},
+		{path: "k8s container", wantErr: true},
+		{path: "k8s pod delete network", wantErr: true},
+		{path: "docker", wantErr: true},
+		{path: " ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			model, action, err := models.Lookup(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Lookup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if model.ExpName != tt.wantTarget || model.ExpScope != "kubernetes" {
+				t.Errorf("Lookup() target = %s, scope = %s, want %s in kubernetes scope", model.ExpName, model.ExpScope, tt.wantTarget)
+			}
+			if (action == nil && tt.wantAction != "") || (action != nil && action.Name() != tt.wantAction) {
+				t.Errorf("Lookup() action = %v, want %s", action, tt.wantAction)
+			}
+			flags := make([]string, 0)
+			for _, flag := range model.ExpFlags {
+				flags = append(flags, flag.Name)
+			}
+			if !reflect.DeepEqual(flags, tt.wantFlags) {
+				t.Errorf("Lookup() flags = %v, want %v", flags, tt.wantFlags)
+			}
+		})
+	}
+	network, _, _ := models.Lookup("k8s pod network")
+	if namespace := findExpFlag(network.ExpFlags, "namespace"); namespace == nil || !namespace.Required {
+		t.Errorf("the namespace flag of network = %v, want the required flag overriding the pod flag", namespace)
+	}
+}
+
+func TestModelsLookupCopy(t *testing.T) {
+	models := newK8sModels()
+	network := models.Models[0].FindSubModel("pod").FindSubModel("network")
+	network.ExpActions[0].ActionFlags = []ExpFlag{{Name: "time", Deprecated: &Deprecation{Replacement: "delay"}}}
+	network.ExpActions[0].ActionConstraints = []FlagConstraint{{Type: OneOf, Flags: []string{"time", "offset"}}}
+	network.ExpActions[0].ActionRequirements = &ActionRequirements{Commands: []string{"tc"}}
+	effective, _, err := models.Lookup("k8s pod network")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	for idx := range effective.ExpFlags {
+		effective.ExpFlags[idx].Desc = "changed"
+	}
+	effective.ExpActions[0].ActionAliases[0] = "dl"
+	effective.ExpActions[0].ActionFlags[0].Desc = "changed"
+	effective.ExpActions[0].ActionFlags[0].Deprecated.Replacement = "latency"
+	effective.ExpActions[0].ActionConstraints[0].Flags[0] = "percent"
+	effective.ExpActions[0].ActionRequirements.Commands[0] = "iptables"
+	for _, model := range []*ExpCommandModel{&models.Models[0], models.Models[0].FindSubModel("pod"), network} {
+		for _, flag := range model.ExpFlags {
+			if flag.Desc != "" {
+				t.Errorf("changing the flags returned by Lookup() changes the %s flag %s", model.ExpName, flag.Name)
+			}
+		}
+	}
+	if action := network.ExpActions[0]; action.ActionAliases[0] != "d" || action.ActionFlags[0].Desc != "" ||
+		action.ActionConstraints[0].Flags[0] != "time" || action.ActionFlags[0].Deprecated.Replacement != "delay" ||
+		action.ActionRequirements.Commands[0] != "tc" {
+		t.Errorf("changing the action returned by Lookup() changes the models, %+v", action)
+	}
+}
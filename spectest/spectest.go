@@ -0,0 +1,299 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package spectest provides the conformance checks for the implementations of
+// spec.ExpModelCommandSpec, spec.Executor and spec.Channel. For example:
+//
+//	func TestCpuSpec(t *testing.T) {
+//		spectest.Run(t, NewCpuCommandModelSpec(), spectest.Options{})
+//	}
+package spectest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/channel"
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+	"github.com/chaosblade-io/chaosblade-spec-go/util"
+)
+
+// Options of the conformance checks
+type Options struct {
+	// Channel is set to the executors before invoking, MockLocalChannel is used if it's nil
+	Channel spec.Channel
+
+	// FlagValues are used for the required flags which are not given by the action example
+	FlagValues map[string]string
+
+	// SkipExec skips the checks invoking the executors
+	SkipExec bool
+}
+
+// Run checks the command spec and the executors of its actions
+func Run(t testing.TB, commandSpec spec.ExpModelCommandSpec, options Options) {
+	t.Helper()
+	run(t, "spec", func(t testing.TB) {
+		CheckCommandSpec(t, commandSpec)
+	})
+	if options.SkipExec {
+		return
+	}
+	run(t, "executor", func(t testing.TB) {
+		CheckExecutors(t, commandSpec, options)
+	})
+}
+
+// run runs the check as the subtest if t is *testing.T, otherwise runs it in place
+func run(t testing.TB, name string, check func(t testing.TB)) {
+	t.Helper()
+	if tt, ok := t.(*testing.T); ok {
+		tt.Run(name, func(t *testing.T) {
+			check(t)
+		})
+		return
+	}
+	check(t)
+}
+
+// CheckCommandSpec checks every action has the executor, the names are not duplicated, the categories are registered,
+// the action flags don't conflict with the target flags, and the examples are parsed into the valid experiment models
+func CheckCommandSpec(t testing.TB, commandSpec spec.ExpModelCommandSpec) {
+	t.Helper()
+	if commandSpec.Name() == "" {
+		t.Errorf("the target name is empty")
+	}
+	if len(commandSpec.Actions()) == 0 {
+		t.Errorf("%s: no actions", commandSpec.Name())
+	}
+	for _, err := range spec.CheckCategories(commandSpec) {
+		t.Errorf("%v", err)
+	}
+	actionNames := make(map[string]bool)
+	for _, action := range commandSpec.Actions() {
+		name := fmt.Sprintf("%s %s", commandSpec.Name(), action.Name())
+		if action.Name() == "" {
+			t.Errorf("%s: the action name is empty", commandSpec.Name())
+		}
+		for _, actionName := range append([]string{action.Name()}, action.Aliases()...) {
+			if actionNames[actionName] {
+				t.Errorf("%s: duplicate action name or alias %s", name, actionName)
+			}
+			actionNames[actionName] = true
+		}
+		if action.Executor() == nil || util.IsNil(action.Executor()) {
+			t.Errorf("%s: the executor is nil", name)
+		}
+		flagNames := make(map[string]bool)
+		for _, flag := range append(append([]spec.ExpFlagSpec{}, action.Matchers()...), action.Flags()...) {
+			if flag.FlagName() == "" {
+				t.Errorf("%s: the flag name is empty", name)
+			}
+			if flagNames[flag.FlagName()] {
+				t.Errorf("%s: duplicate flag %s", name, flag.FlagName())
+			}
+			flagNames[flag.FlagName()] = true
+		}
+		_, conflicts := spec.EffectiveFlags(commandSpec, action)
+		for _, conflict := range conflicts {
+			t.Errorf("flag conflict, %s", conflict)
+		}
+		for _, err := range spec.CheckFlagConstraints(commandSpec, action) {
+			t.Errorf("invalid constraint, %v", err)
+		}
+		if err := util.ValidateActionExamples(commandSpec, action); err != nil {
+			t.Errorf("%s: invalid example, %v", name, err)
+		}
+	}
+}
+
+// CheckExecutors invokes the executor of every action through the channel, and checks
+// the experiment is rejected when the required flags are missing, the destroy command is idempotent
+// and the responses are valid json
+func CheckExecutors(t testing.TB, commandSpec spec.ExpModelCommandSpec, options Options) {
+	t.Helper()
+	ch := options.Channel
+	if ch == nil {
+		ch = channel.NewMockLocalChannel()
+	}
+	for _, action := range commandSpec.Actions() {
+		executor := action.Executor()
+		if executor == nil || util.IsNil(executor) {
+			continue
+		}
+		run(t, action.Name(), func(t testing.TB) {
+			if executor.Name() == "" {
+				t.Errorf("the executor name is empty")
+			}
+			executor.SetChannel(ch)
+			model := actionModel(commandSpec, action, options.FlagValues)
+			uid := fmt.Sprintf("spectest-%s-%s", commandSpec.Name(), action.Name())
+			ctx := context.Background()
+
+			for _, flag := range actionFlags(commandSpec, action) {
+				if !flag.FlagRequired() {
+					continue
+				}
+				missing := copyModel(model)
+				delete(missing.ActionFlags, flag.FlagName())
+				response := exec(t, uid, ctx, executor, missing)
+				if response != nil && response.Success {
+					t.Errorf("create without the required flag %s, got success, want failure", flag.FlagName())
+				}
+			}
+
+			response := exec(t, uid, ctx, executor, copyModel(model))
+			if response == nil || !response.Success {
+				// the experiment can't be created in the current environment, the destroy checks make no sense
+				return
+			}
+			destroyCtx := spec.SetDestroyFlag(ctx, uid)
+			for i := 1; i <= 2; i++ {
+				response := exec(t, uid, destroyCtx, executor, copyModel(model))
+				if response != nil && !response.Success {
+					t.Errorf("destroy #%d failed, %s", i, response.Print())
+				}
+			}
+		})
+	}
+}
+
+// CheckChannel checks the channel methods don't panic and return the reasonable values
+func CheckChannel(t testing.TB, ch spec.Channel) {
+	t.Helper()
+	ctx := context.Background()
+	call(t, "Name", func() {
+		if ch.Name() == "" {
+			t.Errorf("the channel name is empty")
+		}
+	})
+	call(t, "Run", func() {
+		checkResponse(t, "Run", ch.Run(ctx, "echo", "spectest"))
+	})
+	call(t, "IsCommandAvailable", func() {
+		if ch.IsCommandAvailable(ctx, "chaosblade-spectest-not-exist") {
+			t.Errorf("IsCommandAvailable() of the command not exist = true, want false")
+		}
+	})
+	call(t, "GetPsArgs", func() {
+		if ch.GetPsArgs(ctx) == "" {
+			t.Errorf("GetPsArgs() is empty")
+		}
+	})
+	call(t, "GetPidsByProcessName", func() {
+		if _, err := ch.GetPidsByProcessName("", ctx); err == nil {
+			t.Errorf("GetPidsByProcessName() of the blank name returns no error")
+		}
+	})
+	call(t, "ProcessExists", func() {
+		if exists, _ := ch.ProcessExists("-1"); exists {
+			t.Errorf("ProcessExists(-1) = true, want false")
+		}
+	})
+}
+
+// call reports the panic of the channel method as the test error
+func call(t testing.TB, method string, fn func()) {
+	t.Helper()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			t.Errorf("%s() panics, %v", method, recovered)
+		}
+	}()
+	fn()
+}
+
+// exec invokes the executor, the panic is recovered and reported as the test error
+func exec(t testing.TB, uid string, ctx context.Context, executor spec.Executor, model *spec.ExpModel) *spec.Response {
+	t.Helper()
+	response := spec.SafeExec(uid, ctx, executor, model)
+	if response != nil && response.Code == spec.PanicRecovered.Code {
+		t.Errorf("the executor panics, %s", response.Err)
+		return nil
+	}
+	checkResponse(t, "Exec", response)
+	return response
+}
+
+// checkResponse checks the response is not nil and can be decoded after encoding
+func checkResponse(t testing.TB, method string, response *spec.Response) {
+	t.Helper()
+	if response == nil {
+		t.Errorf("%s() returns nil response", method)
+		return
+	}
+	bytes, err := json.Marshal(response)
+	if err != nil {
+		t.Errorf("%s() returns the response which can't be encoded, %v", method, err)
+		return
+	}
+	decoded := spec.Decode(string(bytes), nil)
+	if decoded.Code != response.Code || decoded.Success != response.Success {
+		t.Errorf("%s() returns the response which can't be decoded, %s", method, string(bytes))
+	}
+}
+
+func actionFlags(commandSpec spec.ExpModelCommandSpec, action spec.ExpActionCommandSpec) []spec.ExpFlagSpec {
+	flags, _ := spec.EffectiveFlags(commandSpec, action)
+	return flags
+}
+
+// actionModel returns the model of the first example, the missing required flags are filled by the flag values
+func actionModel(commandSpec spec.ExpModelCommandSpec, action spec.ExpActionCommandSpec, flagValues map[string]string) *spec.ExpModel {
+	var model *spec.ExpModel
+	for _, line := range util.ExtractExampleCommands(action.Example()) {
+		if parsed, err := util.ParseExampleCommand(line, commandSpec); err == nil && parsed != nil && parsed.ActionName == action.Name() {
+			model = parsed
+			break
+		}
+	}
+	if model == nil {
+		model = &spec.ExpModel{
+			Target:      commandSpec.Name(),
+			Scope:       commandSpec.Scope(),
+			ActionName:  action.Name(),
+			ActionFlags: make(map[string]string),
+		}
+	}
+	for _, flag := range actionFlags(commandSpec, action) {
+		if !flag.FlagRequired() || model.ActionFlags[flag.FlagName()] != "" {
+			continue
+		}
+		value := flagValues[flag.FlagName()]
+		switch {
+		case value != "":
+		case flag.FlagDefault() != "":
+			value = flag.FlagDefault()
+		case flag.FlagNoArgs():
+			value = spec.True
+		default:
+			value = "1"
+		}
+		model.ActionFlags[flag.FlagName()] = value
+	}
+	return model
+}
+
+func copyModel(model *spec.ExpModel) *spec.ExpModel {
+	copied := *model
+	copied.ActionFlags = make(map[string]string, len(model.ActionFlags))
+	for k, v := range model.ActionFlags {
+		copied.ActionFlags[k] = v
+	}
+	return &copied
+}
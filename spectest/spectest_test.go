@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spectest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/channel"
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+// fakeExecutor returns success if exec is nil
+type fakeExecutor struct {
+	exec spec.ExecFunc
+}
+
+func (e *fakeExecutor) Name() string {
+	return "fake"
+}
+
+func (e *fakeExecutor) SetChannel(channel spec.Channel) {}
+
+func (e *fakeExecutor) Exec(uid string, ctx context.Context, model *spec.ExpModel) *spec.Response {
+	if e.exec == nil {
+		return spec.ReturnSuccess(uid)
+	}
+	return e.exec(uid, ctx, model)
+}
+
+// errorRecorder records the errors instead of failing the test
+type errorRecorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *errorRecorder) Helper() {}
+
+func (r *errorRecorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func newFakeCommandModel() *spec.ExpCommandModel {
+	commandModel := &spec.ExpCommandModel{
+		ExpName: "network",
+		ExpActions: []spec.ActionModel{
+			{
+				ActionName:    "delay",
+				ActionAliases: []string{"d"},
+				ActionMatchers: []spec.ExpFlag{
+					{Name: "interface", Required: true},
+					{Name: "local-port"},
+				},
+				ActionFlags: []spec.ExpFlag{
+					{Name: "time", Required: true},
+					{Name: "force", NoArgs: true},
+				},
+				ActionExample: `# Access to native 8080 port is delayed by 3 seconds
+blade create network delay --time 3000 --interface eth0 --local-port 8080
+
+# Force the delay
+blade c network d --time=3000 --interface "eth0" --force`,
+			},
+		},
+	}
+	commandModel.ExpExecutor = spec.Chain(&fakeExecutor{}, spec.ValidationMiddleware(commandModel))
+	return commandModel
+}
+
+func TestRun(t *testing.T) {
+	Run(t, newFakeCommandModel(), Options{})
+}
+
+func TestCheckChannel(t *testing.T) {
+	CheckChannel(t, channel.NewLocalChannel())
+}
+
+func TestCheckExecutorsFailures(t *testing.T) {
+	tests := []struct {
+		name      string
+		executor  func(commandModel *spec.ExpCommandModel) spec.Executor
+		wantError string
+	}{
+		{
+			name: "valid",
+			executor: func(commandModel *spec.ExpCommandModel) spec.Executor {
+				return spec.Chain(&fakeExecutor{}, spec.ValidationMiddleware(commandModel))
+			},
+		},
+		{
+			name: "required flag missing",
+			executor: func(commandModel *spec.ExpCommandModel) spec.Executor {
+				return &fakeExecutor{}
+			},
+			wantError: "create without the required flag",
+		},
+		{
+			name: "destroy failed",
+			executor: func(commandModel *spec.ExpCommandModel) spec.Executor {
+				return spec.Chain(&fakeExecutor{exec: func(uid string, ctx context.Context, model *spec.ExpModel) *spec.Response {
+					if _, ok := spec.IsDestroy(ctx); ok {
+						return spec.ResponseFailWithFlags(spec.CommandIllegal, "destroy")
+					}
+					return spec.ReturnSuccess(uid)
+				}}, spec.ValidationMiddleware(commandModel))
+			},
+			wantError: "destroy #1 failed",
+		},
+		{
+			name: "panic",
+			executor: func(commandModel *spec.ExpCommandModel) spec.Executor {
+				return spec.Chain(&fakeExecutor{exec: func(uid string, ctx context.Context, model *spec.ExpModel) *spec.Response {
+					panic("spectest")
+				}}, spec.ValidationMiddleware(commandModel))
+			},
+			wantError: "the executor panics",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commandModel := newFakeCommandModel()
+			commandModel.ExpExecutor = tt.executor(commandModel)
+			recorder := &errorRecorder{TB: t}
+			CheckExecutors(recorder, commandModel, Options{})
+			if tt.wantError == "" {
+				if len(recorder.errors) > 0 {
+					t.Errorf("CheckExecutors() errors = %v, want no errors", recorder.errors)
+				}
+				return
+			}
+			if !containsError(recorder.errors, tt.wantError) {
+				t.Errorf("CheckExecutors() errors = %v, want %q", recorder.errors, tt.wantError)
+			}
+		})
+	}
+}
+
+func containsError(errors []string, want string) bool {
+	for _, err := range errors {
+		if strings.Contains(err, want) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/log"
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+// The request headers of the async create result reporting
+const (
+	UidHeader       = "X-Chaosblade-Uid"
+	TimestampHeader = "X-Chaosblade-Timestamp"
+	SignatureHeader = "X-Chaosblade-Signature"
+)
+
+// AsyncReporter runs the experiment creation in background if the async flag is true,
+// and posts the final spec.Response json to the endpoint flag value
+type AsyncReporter struct {
+	// Retries is the max retry times after the first post fails
+	Retries int
+
+	// Backoff is the interval before the first retry, it's doubled after each retry
+	Backoff time.Duration
+
+	// SecretKey is used to sign the payload, the payload is not signed if it's empty
+	SecretKey string
+}
+
+// NewAsyncReporter returns the reporter which retries 3 times from 1s backoff
+func NewAsyncReporter(secretKey string) *AsyncReporter {
+	return &AsyncReporter{
+		Retries:   3,
+		Backoff:   time.Second,
+		SecretKey: secretKey,
+	}
+}
+
+// IsAsync returns true if the async flag of the experiment is true, false is returned for the nil model
+func IsAsync(model *spec.ExpModel) bool {
+	return model != nil && model.ActionFlags[spec.AsyncFlag] == spec.True
+}
+
+// Exec invokes the executor directly if the experiment is not async or it's a destroy command,
+// otherwise it returns the uid immediately and reports the result to the endpoint after the executor returns
+func (r *AsyncReporter) Exec(uid string, ctx context.Context, executor spec.Executor, model *spec.ExpModel) *spec.Response {
+	if _, ok := spec.IsDestroy(ctx); ok || !IsAsync(model) {
+		return executor.Exec(uid, ctx, model)
+	}
+	endpoint := model.ActionFlags[spec.EndpointFlag]
+	// the background invocation must not be canceled with the caller
+	bgCtx := context.WithoutCancel(ctx)
+	go func() {
+		response := spec.SafeExec(uid, bgCtx, executor, model)
+		if endpoint == "" {
+			log.Infof(bgCtx, "async experiment finished without endpoint, result: %s", response.Print())
+			return
+		}
+		if err := r.Report(bgCtx, endpoint, uid, response); err != nil {
+			log.Errorf(bgCtx, "report async experiment result to %s failed, %v", endpoint, err)
+		}
+	}()
+	return spec.ReturnSuccess(uid)
+}
+
+// Report posts the response to the endpoint with retries
+func (r *AsyncReporter) Report(ctx context.Context, endpoint, uid string, response *spec.Response) error {
+	body, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers := map[string]string{
+		"Content-Type":  "application/json",
+		UidHeader:       uid,
+		TimestampHeader: timestamp,
+	}
+	if r.SecretKey != "" {
+		headers[SignatureHeader] = Sign(r.SecretKey, timestamp, body)
+	}
+	backoff := r.Backoff
+	for attempt := 0; ; attempt++ {
+		result, err, code := PostCurlWithHeaders(endpoint, body, headers)
+		if err == nil && code >= 200 && code < 300 {
+			log.Infof(ctx, "report async experiment result to %s success, %s", endpoint, result)
+			return nil
+		}
+		if err == nil {
+			err = fmt.Errorf("unexpected status code: %d, response: %s", code, result)
+		}
+		if attempt >= r.Retries {
+			return err
+		}
+		log.Warnf(ctx, "report async experiment result to %s failed, retry after %s, %v", endpoint, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the timestamp and the body joined by a dot
+func Sign(secretKey, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
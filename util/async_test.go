@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+// fakeExecutor invokes exec, it returns success if exec is nil
+type fakeExecutor struct {
+	exec func(uid string, ctx context.Context, model *spec.ExpModel) *spec.Response
+}
+
+func (e *fakeExecutor) Name() string {
+	return "fake"
+}
+
+func (e *fakeExecutor) Exec(uid string, ctx context.Context, model *spec.ExpModel) *spec.Response {
+	if e.exec == nil {
+		return spec.ReturnSuccess(uid)
+	}
+	return e.exec(uid, ctx, model)
+}
+
+func (e *fakeExecutor) SetChannel(channel spec.Channel) {}
+
+func TestAsyncReporter_Exec(t *testing.T) {
+	var attempts int32
+	received := make(chan *spec.Response, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(UidHeader) != "e1" {
+			t.Errorf("uid header = %s, want e1", r.Header.Get(UidHeader))
+		}
+		if r.Header.Get(SignatureHeader) != Sign("secret", r.Header.Get(TimestampHeader), body) {
+			t.Errorf("signature header mismatch")
+		}
+		received <- spec.Decode(string(body), nil)
+	}))
+	defer server.Close()
+
+	reporter := NewAsyncReporter("secret")
+	reporter.Backoff = 10 * time.Millisecond
+	model := &spec.ExpModel{
+		Target:      "cpu",
+		ActionName:  "fullload",
+		ActionFlags: map[string]string{spec.AsyncFlag: spec.True, spec.EndpointFlag: server.URL},
+	}
+	executor := &fakeExecutor{exec: func(uid string, ctx context.Context, model *spec.ExpModel) *spec.Response {
+		return spec.ReturnSuccess("done")
+	}}
+	response := reporter.Exec("e1", context.Background(), executor, model)
+	if !response.Success || response.Result != "e1" {
+		t.Fatalf("Exec() = %s, want the uid returned immediately", response.Print())
+	}
+	select {
+	case result := <-received:
+		if !result.Success || result.Result != "done" {
+			t.Errorf("reported response = %s, want the executor result", result.Print())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the result is not reported")
+	}
+}
+
+func TestIsAsync(t *testing.T) {
+	tests := []struct {
+		name  string
+		model *spec.ExpModel
+		want  bool
+	}{
+		{name: "async", model: &spec.ExpModel{ActionFlags: map[string]string{spec.AsyncFlag: spec.True}}, want: true},
+		{name: "sync", model: &spec.ExpModel{}},
+		{name: "nil model"},
+	}
+	for _, tt := range tests {
+		if got := IsAsync(tt.model); got != tt.want {
+			t.Errorf("IsAsync() %s = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
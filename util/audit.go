@@ -0,0 +1,296 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/log"
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+const AuditLog = "audit.log"
+
+// RedactedValue replaces the values of the sensitive flags in the audit events
+const RedactedValue = "******"
+
+// AuditSensitiveFlags are the words of the sensitive flag names, the values of the flags whose names contain
+// any of the words are replaced by RedactedValue, because the audit log is plain text
+var AuditSensitiveFlags = []string{"password", "passwd", "secret", "token", "credential", "access-key"}
+
+// AuditEvent is the record of the experiment creation or destruction, one json object per line
+type AuditEvent struct {
+	Time      time.Time      `json:"time"`
+	Uid       string         `json:"uid"`
+	Operation string         `json:"operation"`
+	Model     *spec.ExpModel `json:"model"`
+	User      string         `json:"user"`
+	Host      string         `json:"host"`
+	Channel   string         `json:"channel,omitempty"`
+	DryRun    bool           `json:"dryRun,omitempty"`
+	// Duration is the invocation cost in milliseconds
+	Duration int64  `json:"duration"`
+	Code     int32  `json:"code"`
+	Success  bool   `json:"success"`
+	Err      string `json:"error,omitempty"`
+}
+
+// AuditLogger appends the audit events to the rotating file, which is separated from chaosblade.log
+type AuditLogger struct {
+	file   string
+	user   string
+	host   string
+	lock   sync.Mutex
+	output *lumberjack.Logger
+}
+
+// NewAuditLogger returns the logger appending to the file, the file is rotated every 30 MB and 10 backups are kept.
+// The file is created with 0644 mode if it doesn't exist, create it with a stricter mode before to restrict
+// the readers, the mode is kept when the file is rotated.
+func NewAuditLogger(file string) *AuditLogger {
+	host, _ := os.Hostname()
+	return &AuditLogger{
+		file: file,
+		user: GetUserName(),
+		host: host,
+		output: &lumberjack.Logger{
+			Filename:   file,
+			MaxSize:    30, // MB
+			MaxBackups: 10,
+			MaxAge:     90, // days
+			Compress:   false,
+		},
+	}
+}
+
+// GetAuditLogFile returns the audit log file in the logs directory returned by GetLogPath
+func GetAuditLogFile(programType int) (string, error) {
+	logPath, err := GetLogPath(programType)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(logPath, AuditLog), nil
+}
+
+// GetUserName returns the name of the current os user, the base name of the user home is returned if the user is unknown
+func GetUserName() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	return path.Base(GetUserHome())
+}
+
+// File returns the audit log file
+func (a *AuditLogger) File() string {
+	return a.file
+}
+
+// Record appends the event, the user and the host are filled if they are empty
+func (a *AuditLogger) Record(event *AuditEvent) error {
+	if event.User == "" {
+		event.User = a.user
+	}
+	if event.Host == "" {
+		event.Host = a.host
+	}
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	_, err = a.output.Write(append(bytes, '\n'))
+	return err
+}
+
+// Close closes the current file
+func (a *AuditLogger) Close() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.output.Close()
+}
+
+// AuditMiddleware records the create and destroy invocations, the channel name is recorded if the channel is not nil.
+// The values of the sensitive flags are redacted, see AuditSensitiveFlags. The nil response of the next executor
+// is recorded as a failure and returned as it is. The experiment is not failed if the event can't be recorded.
+func AuditMiddleware(auditor *AuditLogger, channel spec.Channel) spec.Middleware {
+	return func(next spec.Executor) spec.Executor {
+		return spec.WrapExecutor(next, func(uid string, ctx context.Context, model *spec.ExpModel) *spec.Response {
+			start := time.Now()
+			response := next.Exec(uid, ctx, model)
+			event := &AuditEvent{
+				Time:      start,
+				Uid:       uid,
+				Operation: spec.Create,
+				Model:     redactModel(model),
+				DryRun:    spec.IsDryRun(ctx),
+				Duration:  time.Since(start).Milliseconds(),
+			}
+			if response == nil {
+				event.Err = "the executor returns nil response"
+			} else {
+				event.Code, event.Success, event.Err = response.Code, response.Success, response.Err
+			}
+			if suid, ok := spec.IsDestroy(ctx); ok {
+				event.Operation = spec.Destroy
+				if event.Uid == "" {
+					event.Uid = suid
+				}
+			}
+			if channel != nil {
+				event.Channel = channel.Name()
+			}
+			if err := auditor.Record(event); err != nil {
+				log.Warnf(ctx, "record the audit event to %s failed, %v", auditor.File(), err)
+			}
+			return response
+		})
+	}
+}
+
+// redactModel returns the copy of the model with the sensitive flag values redacted,
+// the model is returned if no flag is sensitive
+func redactModel(model *spec.ExpModel) *spec.ExpModel {
+	if model == nil {
+		return nil
+	}
+	var redacted *spec.ExpModel
+	for name := range model.ActionFlags {
+		if !isSensitiveFlag(name) {
+			continue
+		}
+		if redacted == nil {
+			copied := *model
+			copied.ActionFlags = make(map[string]string, len(model.ActionFlags))
+			for key, value := range model.ActionFlags {
+				copied.ActionFlags[key] = value
+			}
+			redacted = &copied
+		}
+		redacted.ActionFlags[name] = RedactedValue
+	}
+	if redacted == nil {
+		return model
+	}
+	return redacted
+}
+
+func isSensitiveFlag(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range AuditSensitiveFlags {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditFilter selects the audit events, the empty fields match any event
+type AuditFilter struct {
+	Uid       string
+	Operation string
+	Target    string
+	Action    string
+	User      string
+	Since     time.Time
+	Until     time.Time
+	// Limit keeps the latest events if it's positive
+	Limit int
+}
+
+func (f *AuditFilter) matches(event *AuditEvent) bool {
+	if f.Uid != "" && event.Uid != f.Uid ||
+		f.Operation != "" && event.Operation != f.Operation ||
+		f.User != "" && event.User != f.User {
+		return false
+	}
+	if f.Target != "" && (event.Model == nil || event.Model.Target != f.Target) ||
+		f.Action != "" && (event.Model == nil || event.Model.ActionName != f.Action) {
+		return false
+	}
+	if !f.Since.IsZero() && event.Time.Before(f.Since) || !f.Until.IsZero() && event.Time.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// QueryAuditLog reads the events matched from the audit log file and its rotated backups in time order.
+// The line which is not a valid event, for example the one truncated by a crash, is skipped.
+func QueryAuditLog(file string, filter AuditFilter) ([]AuditEvent, error) {
+	files, err := auditLogFiles(file)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]AuditEvent, 0)
+	for _, f := range files {
+		if events, err = readAuditEvents(f, &filter, events); err != nil {
+			return nil, err
+		}
+	}
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[len(events)-filter.Limit:]
+	}
+	return events, nil
+}
+
+// auditLogFiles returns the backups named by lumberjack, for example audit-2006-01-02T15-04-05.000.log,
+// in rotation order and the current file at last
+func auditLogFiles(file string) ([]string, error) {
+	ext := filepath.Ext(file)
+	prefix := strings.TrimSuffix(filepath.Base(file), ext) + "-"
+	backups, err := filepath.Glob(filepath.Join(filepath.Dir(file), prefix+"*"+ext))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(backups)
+	if IsExist(file) {
+		backups = append(backups, file)
+	}
+	return backups, nil
+}
+
+func readAuditEvents(file string, filter *AuditFilter, events []AuditEvent) ([]AuditEvent, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return events, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event AuditEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		if filter.matches(&event) {
+			events = append(events, event)
+		}
+	}
+	return events, scanner.Err()
+}
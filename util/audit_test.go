@@ -0,0 +1,122 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+func TestAuditMiddleware(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, AuditLog)
+	auditor := NewAuditLogger(file)
+	executor := AuditMiddleware(auditor, nil)(&fakeExecutor{})
+
+	model := &spec.ExpModel{Target: "cpu", ActionName: "fullload", ActionFlags: map[string]string{"cpu-percent": "80"}}
+	executor.Exec("e1", context.Background(), model)
+	executor.Exec("e2", context.Background(), &spec.ExpModel{Target: "disk", ActionName: "fill"})
+	executor.Exec("", spec.SetDestroyFlag(context.Background(), "e1"), model)
+	if err := auditor.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	// the truncated line and the rotated backup are read back too
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"uid":"e3","opera`)
+	f.Close()
+	backup := `{"time":"2020-01-01T00:00:00Z","uid":"e0","operation":"create","model":{"target":"cpu","action":"load"},"user":"admin"}` + "\n"
+	// the backup is named by a recent time, otherwise it's removed by the rotation for MaxAge
+	backupFile := "audit-" + time.Now().UTC().Add(-time.Minute).Format("2006-01-02T15-04-05.000") + ".log"
+	if err := os.WriteFile(filepath.Join(dir, backupFile), []byte(backup), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		filter  AuditFilter
+		wantUid []string
+	}{
+		{name: "all", wantUid: []string{"e0", "e1", "e2", "e1"}},
+		{name: "by uid", filter: AuditFilter{Uid: "e1"}, wantUid: []string{"e1", "e1"}},
+		{name: "by operation", filter: AuditFilter{Operation: spec.Destroy}, wantUid: []string{"e1"}},
+		{name: "by target", filter: AuditFilter{Target: "cpu", Action: "fullload"}, wantUid: []string{"e1", "e1"}},
+		{name: "since", filter: AuditFilter{Since: time.Now().Add(-time.Hour)}, wantUid: []string{"e1", "e2", "e1"}},
+		{name: "limit", filter: AuditFilter{Limit: 2}, wantUid: []string{"e2", "e1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := QueryAuditLog(file, tt.filter)
+			if err != nil {
+				t.Fatalf("QueryAuditLog() error = %v", err)
+			}
+			uids := make([]string, len(events))
+			for idx, event := range events {
+				uids[idx] = event.Uid
+			}
+			if len(uids) != len(tt.wantUid) {
+				t.Fatalf("QueryAuditLog() = %v, want %v", uids, tt.wantUid)
+			}
+			for idx := range uids {
+				if uids[idx] != tt.wantUid[idx] {
+					t.Fatalf("QueryAuditLog() = %v, want %v", uids, tt.wantUid)
+				}
+			}
+		})
+	}
+
+	events, _ := QueryAuditLog(file, AuditFilter{Operation: spec.Destroy})
+	if event := events[0]; !event.Success || event.User == "" || event.Model.ActionFlags["cpu-percent"] != "80" {
+		t.Errorf("QueryAuditLog() destroy event = %+v", event)
+	}
+}
+
+func TestAuditMiddlewareRedactAndNilResponse(t *testing.T) {
+	file := filepath.Join(t.TempDir(), AuditLog)
+	auditor := NewAuditLogger(file)
+	nilExecutor := &fakeExecutor{exec: func(uid string, ctx context.Context, model *spec.ExpModel) *spec.Response {
+		return nil
+	}}
+	model := &spec.ExpModel{Target: "mysql", ActionName: "delay", ActionFlags: map[string]string{"password": "123456", "time": "3000"}}
+	if response := AuditMiddleware(auditor, nil)(nilExecutor).Exec("e1", context.Background(), model); response != nil {
+		t.Errorf("Exec() = %v, want nil", response)
+	}
+	if err := auditor.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if model.ActionFlags["password"] != "123456" {
+		t.Errorf("the model flags are changed: %v", model.ActionFlags)
+	}
+	events, err := QueryAuditLog(file, AuditFilter{})
+	if err != nil || len(events) != 1 {
+		t.Fatalf("QueryAuditLog() = %v, %v, want one event", events, err)
+	}
+	event := events[0]
+	if event.Success || event.Err == "" {
+		t.Errorf("QueryAuditLog() event = %+v, want the failure of nil response", event)
+	}
+	if flags := event.Model.ActionFlags; flags["password"] != RedactedValue || flags["time"] != "3000" {
+		t.Errorf("QueryAuditLog() flags = %v, want the password redacted", flags)
+	}
+}
@@ -0,0 +1,282 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+// The shells supported by GenerateCompletion
+const (
+	BashShell = "bash"
+	ZshShell  = "zsh"
+	FishShell = "fish"
+)
+
+// createCommands are the blade sub commands creating the experiment
+var createCommands = []string{spec.Create, "c"}
+
+const bashCompletionTemplate = `# bash completion for {{.Program}}, generated from the chaosblade spec
+_{{.Func}}_completion() {
+    local cur prev
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    COMPREPLY=()
+    if [[ ${COMP_CWORD} -eq 1 ]]; then
+        COMPREPLY=($(compgen -W "{{join .Commands " "}}" -- "${cur}"))
+        return
+    fi
+    case "${COMP_WORDS[1]}" in
+        {{join .Commands "|"}}) ;;
+        *) return ;;
+    esac
+    if [[ ${COMP_CWORD} -eq 2 ]]; then
+        COMPREPLY=($(compgen -W "{{range $i, $t := .Targets}}{{if $i}} {{end}}{{$t.Name}}{{end}}" -- "${cur}"))
+        return
+    fi
+    if [[ ${COMP_CWORD} -eq 3 ]]; then
+        case "${COMP_WORDS[2]}" in
+{{- range .Targets}}
+            {{.Name}}) COMPREPLY=($(compgen -W "{{range $i, $a := .Actions}}{{if $i}} {{end}}{{join $a.Names " "}}{{end}}" -- "${cur}")) ;;
+{{- end}}
+        esac
+        return
+    fi
+    case "${COMP_WORDS[2]} ${COMP_WORDS[3]}" in
+{{- range $t := .Targets}}{{range .Actions}}
+        {{range $i, $n := .Names}}{{if $i}}|{{end}}"{{$t.Name}} {{$n}}"{{end}})
+            case "${prev}" in
+{{- range .Flags}}{{if not .NoArgs}}
+                --{{.Name}}) COMPREPLY=({{with .Enum}}$(compgen -W "{{join . " "}}" -- "${cur}"){{end}}); return ;;
+{{- end}}{{end}}
+            esac
+            COMPREPLY=($(compgen -W "{{range $i, $f := .Flags}}{{if $i}} {{end}}--{{$f.Name}}{{end}}" -- "${cur}"))
+            ;;
+{{- end}}{{end}}
+    esac
+}
+complete -o default -F _{{.Func}}_completion {{.Program}}
+`
+
+const zshCompletionTemplate = `#compdef {{.Program}}
+# zsh completion for {{.Program}}, generated from the chaosblade spec
+_{{.Func}}() {
+    local -a items
+    case ${CURRENT} in
+        2)
+            items=({{range $i, $c := .Commands}}{{if $i}} {{end}}'{{$c}}:create a chaos experiment'{{end}})
+            _describe 'command' items
+            return
+            ;;
+    esac
+    case ${words[2]} in
+        {{join .Commands "|"}}) ;;
+        *) return ;;
+    esac
+    case ${CURRENT} in
+        3)
+            items=(
+{{- range .Targets}}
+                '{{.Name}}:{{zsh .Desc}}'
+{{- end}}
+            )
+            _describe 'target' items
+            ;;
+        4)
+            case ${words[3]} in
+{{- range .Targets}}
+                {{.Name}})
+                    items=(
+{{- range .Actions}}{{$desc := .Desc}}{{range .Names}}
+                        '{{.}}:{{zsh $desc}}'
+{{- end}}{{end}}
+                    )
+                    _describe 'action' items
+                    ;;
+{{- end}}
+            esac
+            ;;
+        *)
+            local key="${words[3]} ${words[4]}"
+            words=(${words[1]} ${words[5,-1]})
+            (( CURRENT -= 3 ))
+            case ${key} in
+{{- range $t := .Targets}}{{range .Actions}}
+                {{range $i, $n := .Names}}{{if $i}}|{{end}}"{{$t.Name}} {{$n}}"{{end}})
+                    _arguments{{range .Flags}} \
+                        '--{{.Name}}[{{zsharg .Desc}}]{{if not .NoArgs}}:{{.Name}}:{{with .Enum}}({{join . " "}}){{else}} {{end}}{{end}}'
+{{- end}}
+                    ;;
+{{- end}}{{end}}
+            esac
+            ;;
+    esac
+}
+compdef _{{.Func}} {{.Program}}
+`
+
+const fishCompletionTemplate = `# fish completion for {{.Program}}, generated from the chaosblade spec
+function __{{.Func}}_needs_target
+    set -l tokens (commandline -opc)
+    test (count $tokens) -eq 2; and contains -- $tokens[2] {{join .Commands " "}}
+end
+
+function __{{.Func}}_needs_action
+    set -l tokens (commandline -opc)
+    test (count $tokens) -eq 3; and contains -- $tokens[2] {{join .Commands " "}}; and test $tokens[3] = $argv[1]
+end
+
+function __{{.Func}}_action
+    set -l tokens (commandline -opc)
+    test (count $tokens) -ge 4; and contains -- $tokens[2] {{join .Commands " "}}; and test $tokens[3] = $argv[1]; and contains -- $tokens[4] $argv[2..-1]
+end
+
+complete -c {{.Program}} -f
+complete -c {{.Program}} -n '__fish_use_subcommand' -a '{{join .Commands " "}}' -d 'create a chaos experiment'
+{{- range $t := .Targets}}
+complete -c {{$.Program}} -n '__{{$.Func}}_needs_target' -a '{{.Name}}' -d '{{fish .Desc}}'
+{{- range .Actions}}{{$a := .}}
+{{- range .Names}}
+complete -c {{$.Program}} -n '__{{$.Func}}_needs_action {{$t.Name}}' -a '{{.}}' -d '{{fish $a.Desc}}'
+{{- end}}
+{{- range .Flags}}
+complete -c {{$.Program}} -n '__{{$.Func}}_action {{$t.Name}} {{join $a.Names " "}}' -l '{{.Name}}'{{if not .NoArgs}}{{with .Enum}} -x -a '{{join . " "}}'{{else}} -r{{end}}{{end}} -d '{{fish .Desc}}'
+{{- end}}
+{{- end}}
+{{- end}}
+`
+
+var (
+	completionFuncs = template.FuncMap{
+		"join":   strings.Join,
+		"zsh":    escapeZshDesc,
+		"zsharg": escapeZshArgumentDesc,
+		"fish":   escapeFishDesc,
+	}
+
+	completionTemplates = map[string]*template.Template{
+		BashShell: template.Must(template.New(BashShell).Funcs(completionFuncs).Parse(bashCompletionTemplate)),
+		ZshShell:  template.Must(template.New(ZshShell).Funcs(completionFuncs).Parse(zshCompletionTemplate)),
+		FishShell: template.Must(template.New(FishShell).Funcs(completionFuncs).Parse(fishCompletionTemplate)),
+	}
+
+	invalidFuncChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+)
+
+type completionFlag struct {
+	Name   string
+	Desc   string
+	NoArgs bool
+	Enum   []string
+}
+
+type completionAction struct {
+	// Names are the action name and aliases
+	Names []string
+	Desc  string
+	Flags []completionFlag
+}
+
+type completionTarget struct {
+	Name    string
+	Desc    string
+	Actions []completionAction
+}
+
+type completionData struct {
+	Program  string
+	Func     string
+	Commands []string
+	Targets  []completionTarget
+}
+
+// GenerateCompletion writes the completion script of the shell for the blade create commands of the models.
+// The program is the command name completed, for example blade.
+func GenerateCompletion(models *spec.Models, shell, program string, writer io.Writer) error {
+	tpl, ok := completionTemplates[shell]
+	if !ok {
+		return fmt.Errorf("unsupported shell: %s", shell)
+	}
+	data := completionData{
+		Program:  program,
+		Func:     invalidFuncChars.ReplaceAllString(program, "_"),
+		Commands: createCommands,
+		Targets:  make([]completionTarget, 0, len(models.Models)),
+	}
+	for idx := range models.Models {
+		model := &models.Models[idx]
+		target := completionTarget{Name: model.Name(), Desc: model.ShortDesc()}
+		for _, action := range model.Actions() {
+			target.Actions = append(target.Actions, completionAction{
+				Names: append([]string{action.Name()}, action.Aliases()...),
+				Desc:  action.ShortDesc(),
+				Flags: completionFlags(actionFlags(model, action)),
+			})
+		}
+		data.Targets = append(data.Targets, target)
+	}
+	return tpl.Execute(writer, data)
+}
+
+// completionFlags removes the duplicate flags, the first one wins
+func completionFlags(flags []spec.ExpFlagSpec) []completionFlag {
+	result := make([]completionFlag, 0, len(flags))
+	names := make(map[string]spec.Empty)
+	for _, flag := range flags {
+		if _, ok := names[flag.FlagName()]; ok {
+			continue
+		}
+		names[flag.FlagName()] = spec.Empty{}
+		result = append(result, completionFlag{
+			Name:   flag.FlagName(),
+			Desc:   flag.FlagDesc(),
+			NoArgs: flag.FlagNoArgs(),
+			Enum:   spec.FlagEnum(flag),
+		})
+	}
+	return result
+}
+
+// firstLine returns the first line of the description, the completion description must be in one line
+func firstLine(desc string) string {
+	desc = strings.TrimSpace(desc)
+	if idx := strings.IndexAny(desc, "\r\n"); idx >= 0 {
+		desc = desc[:idx]
+	}
+	return desc
+}
+
+// escapeZshDesc escapes the description in the single quoted _describe item
+func escapeZshDesc(desc string) string {
+	return strings.ReplaceAll(firstLine(desc), `'`, `'\''`)
+}
+
+// escapeZshArgumentDesc escapes the description in the single quoted _arguments spec
+func escapeZshArgumentDesc(desc string) string {
+	return strings.NewReplacer(`[`, `\[`, `]`, `\]`, `:`, `\:`).Replace(escapeZshDesc(desc))
+}
+
+// escapeFishDesc escapes the description in the single quoted fish string
+func escapeFishDesc(desc string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(firstLine(desc))
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+func TestGenerateCompletion(t *testing.T) {
+	model := newExampleCommandModel("")
+	model.ExpShortDesc = "Network experiment, it's [simple]"
+	model.ExpActions[0].ActionFlags = append(model.ExpActions[0].ActionFlags,
+		spec.ExpFlag{Name: "mode", Desc: "delay mode"})
+	models := &spec.Models{Models: []spec.ExpCommandModel{*model}}
+	tests := []struct {
+		shell   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			shell: BashShell,
+			want: []string{
+				`network) COMPREPLY=($(compgen -W "delay d" -- "${cur}")) ;;`,
+				`--mode) COMPREPLY=(); return ;;`,
+				`COMPREPLY=($(compgen -W "--interface --time --force --mode" -- "${cur}"))`,
+				"complete -o default -F _blade_completion blade",
+			},
+		},
+		{
+			shell: ZshShell,
+			want: []string{
+				`'network:Network experiment, it'\''s [simple]'`,
+				`'--force[]' \`,
+				`'--mode[delay mode]:mode: '`,
+			},
+		},
+		{
+			shell: FishShell,
+			want: []string{
+				`-a 'network' -d 'Network experiment, it\'s [simple]'`,
+				`complete -c blade -n '__blade_action network delay d' -l 'force' -d ''`,
+				`-l 'mode' -r -d 'delay mode'`,
+			},
+		},
+		{
+			shell:   "powershell",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := GenerateCompletion(models, tt.shell, "blade", &buf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GenerateCompletion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("GenerateCompletion() does not contain %q:\n%s", want, buf.String())
+				}
+			}
+			if shell, err := exec.LookPath(tt.shell); err == nil && !tt.wantErr {
+				script := filepath.Join(t.TempDir(), "completion")
+				if err := os.WriteFile(script, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				if output, err := exec.Command(shell, "-n", script).CombinedOutput(); err != nil {
+					t.Errorf("%s -n failed, %v, %s", tt.shell, err, output)
+				}
+			}
+		})
+	}
+}
+
+// enumFlag is the flag spec accepting the enumerated values only
+type enumFlag struct {
+	spec.ExpFlag
+	enum []string
+}
+
+func (f *enumFlag) FlagEnum() []string {
+	return f.enum
+}
+
+func TestGenerateCompletionEnum(t *testing.T) {
+	flags := completionFlags([]spec.ExpFlagSpec{
+		&enumFlag{ExpFlag: spec.ExpFlag{Name: "mode", Desc: "delay mode"}, enum: []string{"fixed", "random"}},
+		&spec.ExpFlag{Name: "time"},
+	})
+	data := completionData{
+		Program:  "blade",
+		Func:     "blade",
+		Commands: createCommands,
+		Targets: []completionTarget{{
+			Name:    "network",
+			Actions: []completionAction{{Names: []string{"delay"}, Flags: flags}},
+		}},
+	}
+	tests := map[string]string{
+		BashShell: `--mode) COMPREPLY=($(compgen -W "fixed random" -- "${cur}")); return ;;`,
+		ZshShell:  `'--mode[delay mode]:mode:(fixed random)'`,
+		FishShell: `-l 'mode' -x -a 'fixed random' -d 'delay mode'`,
+	}
+	for shell, want := range tests {
+		var buf bytes.Buffer
+		if err := completionTemplates[shell].Execute(&buf, data); err != nil {
+			t.Fatalf("%s completion error = %v", shell, err)
+		}
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("%s completion does not contain %q:\n%s", shell, want, buf.String())
+		}
+	}
+}
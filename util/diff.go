@@ -0,0 +1,255 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+// SpecChangeKind is the kind of the spec change
+type SpecChangeKind string
+
+const (
+	SpecAdded   SpecChangeKind = "added"
+	SpecRemoved SpecChangeKind = "removed"
+	SpecChanged SpecChangeKind = "changed"
+)
+
+// SpecChange is one difference between two spec models. It's breaking if the experiments
+// created by the old spec may be rejected, parsed differently or behave differently by the new spec.
+type SpecChange struct {
+	Kind SpecChangeKind `json:"kind"`
+	// Target is the target path separated by blanks, for example "k8s pod"
+	Target string `json:"target"`
+	Scope  string `json:"scope,omitempty"`
+	Action string `json:"action,omitempty"`
+	Flag   string `json:"flag,omitempty"`
+	// Field is the changed attribute, for example alias, required or default
+	Field    string `json:"field,omitempty"`
+	Old      string `json:"old,omitempty"`
+	New      string `json:"new,omitempty"`
+	Breaking bool   `json:"breaking"`
+}
+
+func (c SpecChange) String() string {
+	path := c.Target
+	if c.Scope != "" {
+		path += "(" + c.Scope + ")"
+	}
+	if c.Action != "" {
+		path += " " + c.Action
+	}
+	if c.Flag != "" {
+		path += " --" + c.Flag
+	}
+	level := "compatible"
+	if c.Breaking {
+		level = "breaking"
+	}
+	if c.Field == "" {
+		return fmt.Sprintf("[%s] %s %s", level, path, c.Kind)
+	}
+	return fmt.Sprintf("[%s] %s %s %s: %q -> %q", level, path, c.Field, c.Kind, c.Old, c.New)
+}
+
+// SpecDiffReport is the result of DiffModels, it can be marshaled to json
+type SpecDiffReport struct {
+	Changes []SpecChange `json:"changes"`
+}
+
+// HasBreaking returns true if any change is breaking
+func (r *SpecDiffReport) HasBreaking() bool {
+	return len(r.BreakingChanges()) > 0
+}
+
+// BreakingChanges returns the breaking changes
+func (r *SpecDiffReport) BreakingChanges() []SpecChange {
+	changes := make([]SpecChange, 0)
+	for _, change := range r.Changes {
+		if change.Breaking {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func (r *SpecDiffReport) add(change SpecChange) {
+	r.Changes = append(r.Changes, change)
+}
+
+// DiffModels compares the targets, sub targets, actions, aliases and flags of the old and new models,
+// the targets are matched by the scope and the name, the changes are reported in the order they appear in the models
+func DiffModels(oldModels, newModels *spec.Models) *SpecDiffReport {
+	report := &SpecDiffReport{Changes: make([]SpecChange, 0)}
+	newTargets := indexModels(newModels)
+	oldTargets := indexModels(oldModels)
+	for idx := range oldModels.Models {
+		oldModel := &oldModels.Models[idx]
+		newModel, ok := newTargets[modelKey(oldModel)]
+		if !ok {
+			report.add(SpecChange{Kind: SpecRemoved, Target: oldModel.ExpName, Scope: oldModel.ExpScope, Breaking: true})
+			continue
+		}
+		diffTarget(report, []string{oldModel.ExpName}, oldModel, newModel, oldModel, newModel)
+	}
+	for idx := range newModels.Models {
+		newModel := &newModels.Models[idx]
+		if _, ok := oldTargets[modelKey(newModel)]; !ok {
+			report.add(SpecChange{Kind: SpecAdded, Target: newModel.ExpName, Scope: newModel.ExpScope})
+		}
+	}
+	return report
+}
+
+func indexModels(models *spec.Models) map[string]*spec.ExpCommandModel {
+	targets := make(map[string]*spec.ExpCommandModel, len(models.Models))
+	for idx := range models.Models {
+		targets[modelKey(&models.Models[idx])] = &models.Models[idx]
+	}
+	return targets
+}
+
+// diffTarget compares the actions of the target at the path with the inherited flags, then the sub targets
+func diffTarget(report *SpecDiffReport, path []string, oldRoot, newRoot, oldModel, newModel *spec.ExpCommandModel) {
+	target := strings.Join(path, " ")
+	oldEffective, _, _ := (&spec.Models{Models: []spec.ExpCommandModel{*oldRoot}}).Lookup(target)
+	newEffective, _, _ := (&spec.Models{Models: []spec.ExpCommandModel{*newRoot}}).Lookup(target)
+	diffActions(report, SpecChange{Target: target, Scope: oldEffective.ExpScope}, oldEffective, newEffective)
+	for idx := range oldModel.ExpSubModels {
+		oldChild := &oldModel.ExpSubModels[idx]
+		childPath := append(slices.Clone(path), oldChild.ExpName)
+		newChild := newModel.FindSubModel(oldChild.ExpName)
+		if newChild == nil {
+			report.add(SpecChange{
+				Kind: SpecRemoved, Target: strings.Join(childPath, " "), Scope: childScope(oldEffective, oldChild), Breaking: true,
+			})
+			continue
+		}
+		diffTarget(report, childPath, oldRoot, newRoot, oldChild, newChild)
+	}
+	for idx := range newModel.ExpSubModels {
+		newChild := &newModel.ExpSubModels[idx]
+		if oldModel.FindSubModel(newChild.ExpName) == nil {
+			childPath := append(slices.Clone(path), newChild.ExpName)
+			report.add(SpecChange{Kind: SpecAdded, Target: strings.Join(childPath, " "), Scope: childScope(newEffective, newChild)})
+		}
+	}
+}
+
+// childScope returns the scope of the sub target, which inherits the scope of the parent if not set
+func childScope(parent, child *spec.ExpCommandModel) string {
+	if child.ExpScope != "" {
+		return child.ExpScope
+	}
+	return parent.ExpScope
+}
+
+func diffActions(report *SpecDiffReport, base SpecChange, oldModel, newModel *spec.ExpCommandModel) {
+	with := func(kind SpecChangeKind, action string, breaking bool) SpecChange {
+		change := base
+		change.Kind, change.Action, change.Breaking = kind, action, breaking
+		return change
+	}
+	for _, oldAction := range oldModel.Actions() {
+		newAction := spec.FindAction(newModel, oldAction.Name())
+		if newAction == nil {
+			report.add(with(SpecRemoved, oldAction.Name(), true))
+			continue
+		}
+		action := oldAction.Name()
+		for _, alias := range oldAction.Aliases() {
+			if !slices.Contains(newAction.Aliases(), alias) && newAction.Name() != alias {
+				change := with(SpecRemoved, action, true)
+				change.Field, change.Old = "alias", alias
+				report.add(change)
+			}
+		}
+		for _, alias := range newAction.Aliases() {
+			if !slices.Contains(oldAction.Aliases(), alias) {
+				change := with(SpecAdded, action, false)
+				change.Field, change.New = "alias", alias
+				report.add(change)
+			}
+		}
+		flagBase := base
+		flagBase.Action = action
+		diffFlags(report, flagBase, actionFlags(oldModel, oldAction), actionFlags(newModel, newAction))
+	}
+	for _, newAction := range newModel.Actions() {
+		if spec.FindAction(oldModel, newAction.Name()) == nil {
+			report.add(with(SpecAdded, newAction.Name(), false))
+		}
+	}
+}
+
+func diffFlags(report *SpecDiffReport, base SpecChange, oldFlags, newFlags []spec.ExpFlagSpec) {
+	newIndex := make(map[string]spec.ExpFlagSpec, len(newFlags))
+	for _, flag := range newFlags {
+		newIndex[flag.FlagName()] = flag
+	}
+	oldIndex := make(map[string]spec.ExpFlagSpec, len(oldFlags))
+	for _, oldFlag := range oldFlags {
+		if _, ok := oldIndex[oldFlag.FlagName()]; ok {
+			continue
+		}
+		oldIndex[oldFlag.FlagName()] = oldFlag
+		change := base
+		change.Flag = oldFlag.FlagName()
+		newFlag, ok := newIndex[oldFlag.FlagName()]
+		if !ok {
+			change.Kind, change.Breaking = SpecRemoved, true
+			report.add(change)
+			continue
+		}
+		change.Kind = SpecChanged
+		diffBool := func(field string, oldValue, newValue, breaking bool) {
+			if oldValue != newValue {
+				change.Field, change.Old, change.New = field, strconv.FormatBool(oldValue), strconv.FormatBool(newValue)
+				change.Breaking = breaking
+				report.add(change)
+			}
+		}
+		// the saved experiments may miss the flag which becomes required
+		diffBool("required", oldFlag.FlagRequired(), newFlag.FlagRequired(), newFlag.FlagRequired())
+		diffBool("requiredWhenDestroyed", oldFlag.FlagRequiredWhenDestroyed(), newFlag.FlagRequiredWhenDestroyed(),
+			newFlag.FlagRequiredWhenDestroyed())
+		// the flag value is parsed differently
+		diffBool("noArgs", oldFlag.FlagNoArgs(), newFlag.FlagNoArgs(), true)
+		// the saved experiments without the flag behave differently
+		if oldFlag.FlagDefault() != newFlag.FlagDefault() {
+			change.Field, change.Old, change.New = "default", oldFlag.FlagDefault(), newFlag.FlagDefault()
+			change.Breaking = true
+			report.add(change)
+		}
+	}
+	for _, newFlag := range newFlags {
+		if _, ok := oldIndex[newFlag.FlagName()]; ok {
+			continue
+		}
+		oldIndex[newFlag.FlagName()] = newFlag
+		// the saved experiments miss the new required flag
+		change := base
+		change.Kind, change.Flag = SpecAdded, newFlag.FlagName()
+		change.Breaking = newFlag.FlagRequired() || newFlag.FlagRequiredWhenDestroyed()
+		report.add(change)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+func TestDiffModels(t *testing.T) {
+	oldModels := &spec.Models{Models: []spec.ExpCommandModel{
+		{
+			ExpName: "network",
+			ExpActions: []spec.ActionModel{
+				{
+					ActionName:    "delay",
+					ActionAliases: []string{"d"},
+					ActionFlags: []spec.ExpFlag{
+						{Name: "time", Required: true},
+						{Name: "offset", Default: "10"},
+						{Name: "force", NoArgs: true},
+						{Name: "interface"},
+					},
+				},
+				{ActionName: "loss"},
+			},
+		},
+		{ExpName: "disk"},
+	}}
+	newModels := &spec.Models{Models: []spec.ExpCommandModel{
+		{
+			ExpName: "network",
+			ExpActions: []spec.ActionModel{
+				{
+					ActionName:    "delay",
+					ActionAliases: []string{"dl"},
+					ActionFlags: []spec.ExpFlag{
+						{Name: "time"},
+						{Name: "offset", Default: "20"},
+						{Name: "force"},
+						{Name: "percent", Required: true},
+					},
+				},
+				{ActionName: "corrupt"},
+			},
+		},
+		{ExpName: "cpu"},
+	}}
+	want := []SpecChange{
+		{Kind: SpecRemoved, Target: "network", Action: "delay", Field: "alias", Old: "d", Breaking: true},
+		{Kind: SpecAdded, Target: "network", Action: "delay", Field: "alias", New: "dl"},
+		{Kind: SpecChanged, Target: "network", Action: "delay", Flag: "time", Field: "required", Old: "true", New: "false"},
+		{Kind: SpecChanged, Target: "network", Action: "delay", Flag: "offset", Field: "default", Old: "10", New: "20", Breaking: true},
+		{Kind: SpecChanged, Target: "network", Action: "delay", Flag: "force", Field: "noArgs", Old: "true", New: "false", Breaking: true},
+		{Kind: SpecRemoved, Target: "network", Action: "delay", Flag: "interface", Breaking: true},
+		{Kind: SpecAdded, Target: "network", Action: "delay", Flag: "percent", Breaking: true},
+		{Kind: SpecRemoved, Target: "network", Action: "loss", Breaking: true},
+		{Kind: SpecAdded, Target: "network", Action: "corrupt"},
+		{Kind: SpecRemoved, Target: "disk", Breaking: true},
+		{Kind: SpecAdded, Target: "cpu"},
+	}
+	report := DiffModels(oldModels, newModels)
+	if !reflect.DeepEqual(report.Changes, want) {
+		t.Errorf("DiffModels() changes:\n%v\nwant:\n%v", report.Changes, want)
+	}
+	if !report.HasBreaking() || len(report.BreakingChanges()) != 7 {
+		t.Errorf("BreakingChanges() = %v, want 7 changes", report.BreakingChanges())
+	}
+	if report := DiffModels(oldModels, oldModels); len(report.Changes) != 0 {
+		t.Errorf("DiffModels() of the same models = %v, want no changes", report.Changes)
+	}
+}
+
+func TestDiffModelsScopesAndSubModels(t *testing.T) {
+	oldModels := &spec.Models{Models: []spec.ExpCommandModel{
+		{ExpName: "cpu", ExpScope: "host", ExpActions: []spec.ActionModel{{ActionName: "load"}}},
+		{
+			ExpName:  "cpu",
+			ExpScope: "pod",
+			ExpFlags: []spec.ExpFlag{{Name: "names"}},
+			ExpActions: []spec.ActionModel{
+				{ActionName: "load", ActionFlags: []spec.ExpFlag{{Name: "cpu-percent"}}},
+			},
+			ExpSubModels: []spec.ExpCommandModel{
+				{ExpName: "container", ExpActions: []spec.ActionModel{{ActionName: "load"}}},
+				{ExpName: "process"},
+			},
+		},
+	}}
+	newModels := &spec.Models{Models: []spec.ExpCommandModel{
+		{
+			ExpName:  "cpu",
+			ExpScope: "pod",
+			ExpFlags: []spec.ExpFlag{{Name: "names", Required: true}},
+			ExpActions: []spec.ActionModel{
+				{ActionName: "load", ActionFlags: []spec.ExpFlag{{Name: "cpu-percent"}}},
+			},
+			ExpSubModels: []spec.ExpCommandModel{
+				{ExpName: "container", ExpActions: []spec.ActionModel{{ActionName: "load"}, {ActionName: "fullload"}}},
+				{ExpName: "node", ExpScope: "host"},
+			},
+		},
+		{ExpName: "cpu", ExpScope: "host", ExpActions: []spec.ActionModel{{ActionName: "load"}}},
+	}}
+	want := []SpecChange{
+		{Kind: SpecChanged, Target: "cpu", Scope: "pod", Action: "load", Flag: "names", Field: "required", Old: "false", New: "true", Breaking: true},
+		{Kind: SpecChanged, Target: "cpu container", Scope: "pod", Action: "load", Flag: "names", Field: "required", Old: "false", New: "true", Breaking: true},
+		{Kind: SpecAdded, Target: "cpu container", Scope: "pod", Action: "fullload"},
+		{Kind: SpecRemoved, Target: "cpu process", Scope: "pod", Breaking: true},
+		{Kind: SpecAdded, Target: "cpu node", Scope: "host"},
+	}
+	report := DiffModels(oldModels, newModels)
+	if !reflect.DeepEqual(report.Changes, want) {
+		t.Errorf("DiffModels() changes:\n%v\nwant:\n%v", report.Changes, want)
+	}
+	if got := want[1].String(); got != `[breaking] cpu container(pod) load --names required changed: "false" -> "true"` {
+		t.Errorf("SpecChange.String() = %s", got)
+	}
+}
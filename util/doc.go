@@ -0,0 +1,298 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+// DocFormat is the format of the reference pages
+type DocFormat string
+
+const (
+	MarkdownDoc DocFormat = "md"
+	HTMLDoc     DocFormat = "html"
+)
+
+const markdownDocTemplate = `# {{.ExpName}}
+
+{{.ExpShortDesc}}
+{{- with .ExpDeprecated}}
+
+> **Deprecated:** {{.Warning "target" $.ExpName}}
+{{- end}}
+{{- if .ExpLongDesc}}
+
+{{.ExpLongDesc}}
+{{- end}}
+{{- if .ExpScope}}
+
+Scope: ` + "`{{.ExpScope}}`" + `
+{{- end}}
+{{- with .ExpFlags}}
+
+## Common Flags
+
+{{template "flags" .}}
+{{- end}}
+{{- range .ExpActions}}
+
+## {{.ActionName}}
+
+{{.ActionShortDesc}}
+{{- $action := .ActionName}}
+{{- with .ActionDeprecated}}
+
+> **Deprecated:** {{.Warning "action" $action}}
+{{- end}}
+{{- if .ActionLongDesc}}
+
+{{.ActionLongDesc}}
+{{- end}}
+{{- with .ActionAliases}}
+
+Aliases: {{join . ", "}}
+{{- end}}
+{{- with .ActionCategories}}
+
+Categories: {{join . ", "}}
+{{- end}}
+{{- with .ActionPrograms}}
+
+Programs: {{join . ", "}}
+{{- end}}
+{{- with .ActionMatchers}}
+
+### Matchers
+
+{{template "flags" .}}
+{{- end}}
+{{- with .ActionFlags}}
+
+### Flags
+
+{{template "flags" .}}
+{{- end}}
+{{- with .ActionConstraints}}
+
+### Constraints
+{{range .}}
+- {{.}}
+{{- end}}
+{{- end}}
+{{- with .ActionRequirements}}
+
+### Requirements
+{{range .Strings}}
+- {{.}}
+{{- end}}
+{{- end}}
+{{- if .ActionExample}}
+
+### Example
+
+{{fence .ActionExample}}
+{{.ActionExample}}
+{{fence .ActionExample}}
+{{- end}}
+{{- end}}
+{{define "flags"}}| Name | Description | Required | Default |
+| --- | --- | --- | --- |
+{{- range .}}
+| ` + "`--{{.Name}}`" + ` | {{cell .Desc}} | {{.Required}} | {{cell .Default}} |
+{{- end}}
+{{- end}}
+`
+
+const htmlDocTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.ExpName}}</title>
+</head>
+<body>
+<h1>{{.ExpName}}</h1>
+<p>{{.ExpShortDesc}}</p>
+{{- with .ExpDeprecated}}
+<p><strong>Deprecated:</strong> {{.Warning "target" $.ExpName}}</p>
+{{- end}}
+{{- if .ExpLongDesc}}
+<p>{{.ExpLongDesc}}</p>
+{{- end}}
+{{- if .ExpScope}}
+<p>Scope: <code>{{.ExpScope}}</code></p>
+{{- end}}
+{{- with .ExpFlags}}
+<h2>Common Flags</h2>
+{{template "flags" .}}
+{{- end}}
+{{- range .ExpActions}}
+<h2 id="{{.ActionName}}">{{.ActionName}}</h2>
+<p>{{.ActionShortDesc}}</p>
+{{- $action := .ActionName}}
+{{- with .ActionDeprecated}}
+<p><strong>Deprecated:</strong> {{.Warning "action" $action}}</p>
+{{- end}}
+{{- if .ActionLongDesc}}
+<p>{{.ActionLongDesc}}</p>
+{{- end}}
+{{- with .ActionAliases}}
+<p>Aliases: {{join . ", "}}</p>
+{{- end}}
+{{- with .ActionCategories}}
+<p>Categories: {{join . ", "}}</p>
+{{- end}}
+{{- with .ActionPrograms}}
+<p>Programs: {{join . ", "}}</p>
+{{- end}}
+{{- with .ActionMatchers}}
+<h3>Matchers</h3>
+{{template "flags" .}}
+{{- end}}
+{{- with .ActionFlags}}
+<h3>Flags</h3>
+{{template "flags" .}}
+{{- end}}
+{{- with .ActionConstraints}}
+<h3>Constraints</h3>
+<ul>
+{{- range .}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- with .ActionRequirements}}
+<h3>Requirements</h3>
+<ul>
+{{- range .Strings}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .ActionExample}}
+<h3>Example</h3>
+<pre><code>{{.ActionExample}}</code></pre>
+{{- end}}
+{{- end}}
+</body>
+</html>
+{{define "flags"}}<table>
+<tr><th>Name</th><th>Description</th><th>Required</th><th>Default</th></tr>
+{{- range .}}
+<tr><td><code>--{{.Name}}</code></td><td>{{.Desc}}</td><td>{{.Required}}</td><td>{{.Default}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+`
+
+var (
+	markdownDoc = template.Must(template.New("markdown").Funcs(template.FuncMap{
+		"join":  strings.Join,
+		"cell":  markdownCell,
+		"fence": markdownFence,
+	}).Parse(markdownDocTemplate))
+
+	htmlDoc = htmltemplate.Must(htmltemplate.New("html").Funcs(htmltemplate.FuncMap{
+		"join": strings.Join,
+	}).Parse(htmlDocTemplate))
+)
+
+// markdownCell escapes the pipes and line breaks in the table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(strings.TrimSpace(value), "\n", "<br>")
+}
+
+// markdownFence returns the code fence longer than any backtick run in the code, at least three backticks
+func markdownFence(code string) string {
+	longest, run := 0, 0
+	for _, r := range code {
+		if r != '`' {
+			run = 0
+			continue
+		}
+		run++
+		longest = max(longest, run)
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+// RenderDoc writes the reference page of the target in the format
+func RenderDoc(model *spec.ExpCommandModel, format DocFormat, writer io.Writer) error {
+	switch format {
+	case MarkdownDoc:
+		return markdownDoc.Execute(writer, model)
+	case HTMLDoc:
+		return htmlDoc.Execute(writer, model)
+	default:
+		return fmt.Errorf("unsupported doc format: %s", format)
+	}
+}
+
+// GenerateDocs writes one reference page per target to the directory, the file is named by the target
+// and the scope if set, for example cpu.md and cpu-pod.md. It returns the files written.
+func GenerateDocs(models *spec.Models, format DocFormat, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(models.Models))
+	for idx := range models.Models {
+		model := &models.Models[idx]
+		file := filepath.Join(dir, docFileName(model, format))
+		if err := writeDoc(model, format, file); err != nil {
+			return files, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func docFileName(model *spec.ExpCommandModel, format DocFormat) string {
+	if model.ExpScope == "" {
+		return fmt.Sprintf("%s.%s", model.ExpName, format)
+	}
+	return fmt.Sprintf("%s-%s.%s", model.ExpName, model.ExpScope, format)
+}
+
+// GenerateDocsFromYaml writes the reference pages of the spec file generated by CreateYamlFile
+func GenerateDocsFromYaml(specFile string, format DocFormat, dir string) ([]string, error) {
+	models, err := ParseSpecsToModel(specFile, nil)
+	if err != nil {
+		return nil, err
+	}
+	return GenerateDocs(models, format, dir)
+}
+
+func writeDoc(model *spec.ExpCommandModel, format DocFormat, file string) error {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := RenderDoc(model, format, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
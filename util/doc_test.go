@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+func TestGenerateDocs(t *testing.T) {
+	models := &spec.Models{Models: []spec.ExpCommandModel{*newExampleCommandModel("blade create network delay --time 3000 --interface eth0")}}
+	models.Models[0].ExpActions[0].ActionFlags[0].Desc = "delay time | ms\nfor example 3000"
+	models.Models[0].ExpActions[0].ActionRequirements = &spec.ActionRequirements{OS: []string{"linux"}, Commands: []string{"tc"}}
+	tests := []struct {
+		format DocFormat
+		want   []string
+	}{
+		{
+			format: MarkdownDoc,
+			want: []string{
+				"# network",
+				"| `--time` | delay time \\| ms<br>for example 3000 | true |  |",
+				"blade create network delay --time 3000 --interface eth0",
+				"- commands: tc",
+			},
+		},
+		{
+			format: HTMLDoc,
+			want: []string{
+				"<h1>network</h1>",
+				"<tr><td><code>--interface</code></td><td></td><td>true</td><td></td></tr>",
+				"<li>os: linux</li>",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			dir := t.TempDir()
+			files, err := GenerateDocs(models, tt.format, dir)
+			if err != nil {
+				t.Fatalf("GenerateDocs() error = %v", err)
+			}
+			if want := filepath.Join(dir, "network."+string(tt.format)); len(files) != 1 || files[0] != want {
+				t.Fatalf("GenerateDocs() = %v, want [%s]", files, want)
+			}
+			bytes, err := os.ReadFile(files[0])
+			if err != nil {
+				t.Fatalf("read doc error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(bytes), want) {
+					t.Errorf("GenerateDocs() content does not contain %q:\n%s", want, bytes)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateDocsScopes(t *testing.T) {
+	host := newExampleCommandModel("blade create network delay --time 3000 --interface eth0")
+	pod := newExampleCommandModel("```\nblade create network delay --time 3000 --interface eth0\n```")
+	pod.ExpScope = "pod"
+	dir := t.TempDir()
+	files, err := GenerateDocs(&spec.Models{Models: []spec.ExpCommandModel{*host, *pod}}, MarkdownDoc, dir)
+	if err != nil {
+		t.Fatalf("GenerateDocs() error = %v", err)
+	}
+	want := []string{filepath.Join(dir, "network.md"), filepath.Join(dir, "network-pod.md")}
+	if len(files) != len(want) || files[0] != want[0] || files[1] != want[1] {
+		t.Fatalf("GenerateDocs() = %v, want %v", files, want)
+	}
+	bytes, err := os.ReadFile(files[1])
+	if err != nil {
+		t.Fatalf("read doc error = %v", err)
+	}
+	if !strings.Contains(string(bytes), "````\n```\nblade create network delay") {
+		t.Errorf("GenerateDocs() content does not fence the example with four backticks:\n%s", bytes)
+	}
+}
+
+func TestMarkdownFence(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{code: "blade create cpu load", want: "```"},
+		{code: "use `--timeout`", want: "```"},
+		{code: "```\nblade create cpu load\n```", want: "````"},
+		{code: "`````", want: "``````"},
+	}
+	for _, tt := range tests {
+		if got := markdownFence(tt.code); got != tt.want {
+			t.Errorf("markdownFence(%q) = %s, want %s", tt.code, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/log"
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+// commonFlags are accepted by every action besides the flags in the spec
+var commonFlags = map[string]spec.Empty{
+	spec.TimeoutFlag: {}, spec.AsyncFlag: {}, spec.EndpointFlag: {}, spec.Uid: {}, spec.DebugFlag: {}, spec.DryRunFlag: {},
+}
+
+// ExtractExampleCommands returns the blade create command lines in the action example
+func ExtractExampleCommands(example string) []string {
+	commands := make([]string, 0)
+	for _, line := range strings.Split(example, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.HasSuffix(fields[0], "blade") {
+			continue
+		}
+		if fields[1] == spec.Create || fields[1] == "c" {
+			commands = append(commands, strings.TrimSpace(line))
+		}
+	}
+	return commands
+}
+
+// ParseExampleCommand returns the experiment model of the blade create command line in the form of
+// blade create <target> <action> [flags], returns nil if the command line is not for the target of the command spec
+func ParseExampleCommand(line string, commandSpec spec.ExpModelCommandSpec) (*spec.ExpModel, error) {
+	tokens, err := splitCommandLine(line)
+	if err != nil {
+		return nil, err
+	}
+	idx := 2
+	// the shorthand of the blade global flags may precede the target, for example -d
+	for idx < len(tokens) && strings.HasPrefix(tokens[idx], "-") {
+		idx++
+	}
+	if idx >= len(tokens) || tokens[idx] != commandSpec.Name() {
+		return nil, nil
+	}
+	if idx+1 >= len(tokens) || strings.HasPrefix(tokens[idx+1], "-") {
+		return nil, fmt.Errorf("%s: action missing", line)
+	}
+	action := spec.FindAction(commandSpec, tokens[idx+1])
+	if action == nil {
+		return nil, fmt.Errorf("%s: action %s not found", line, tokens[idx+1])
+	}
+	noArgs := make(map[string]bool)
+	for _, flag := range actionFlags(commandSpec, action) {
+		noArgs[flag.FlagName()] = flag.FlagNoArgs()
+	}
+	model := &spec.ExpModel{
+		Target:      commandSpec.Name(),
+		Scope:       commandSpec.Scope(),
+		ActionName:  action.Name(),
+		ActionFlags: make(map[string]string),
+	}
+	for idx = idx + 2; idx < len(tokens); idx++ {
+		token := tokens[idx]
+		if !strings.HasPrefix(token, "-") {
+			return nil, fmt.Errorf("%s: unexpected argument %s", line, token)
+		}
+		if !strings.HasPrefix(token, "--") {
+			// the shorthand of the blade global flags, for example -d
+			continue
+		}
+		name, value, hasValue := strings.Cut(token[2:], "=")
+		if !hasValue {
+			value = spec.True
+			if !noArgs[name] && idx+1 < len(tokens) && isFlagValue(tokens[idx+1]) {
+				idx++
+				value = tokens[idx]
+			}
+		}
+		model.ActionFlags[name] = value
+	}
+	return model, nil
+}
+
+// ValidateActionExamples parses the examples of the action, and checks the flags are declared
+// and the required flags are present. The examples for other targets or actions are ignored.
+func ValidateActionExamples(commandSpec spec.ExpModelCommandSpec, action spec.ExpActionCommandSpec) error {
+	declared := make(map[string]spec.Empty)
+	for _, flag := range actionFlags(commandSpec, action) {
+		declared[flag.FlagName()] = spec.Empty{}
+	}
+	errs := make([]error, 0)
+	for _, line := range ExtractExampleCommands(action.Example()) {
+		model, err := ParseExampleCommand(line, commandSpec)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if model == nil || model.ActionName != action.Name() {
+			continue
+		}
+		names := make([]string, 0, len(model.ActionFlags))
+		for name := range model.ActionFlags {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			_, ok := declared[name]
+			if _, common := commonFlags[name]; !ok && !common {
+				errs = append(errs, fmt.Errorf("%s: flag %s not declared", line, name))
+			}
+		}
+		if response, ok := spec.ValidateExpModel(context.Background(), commandSpec, model); !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", line, response.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateModels validates the flag conflicts, the flag constraints and the action examples of the models.
+// The unknown categories are logged as warnings, because the executor projects may not register their categories,
+// see spec.RegisterCategory.
+func ValidateModels(models *spec.Models) error {
+	errs := make([]error, 0)
+	for idx := range models.Models {
+		model := &models.Models[idx]
+		for _, err := range spec.CheckCategories(model) {
+			log.Warnf(context.Background(), "%v", err)
+		}
+		for _, conflict := range spec.FlagConflicts(model) {
+			errs = append(errs, fmt.Errorf("flag conflict, %s", conflict))
+		}
+		for _, action := range model.Actions() {
+			errs = append(errs, spec.CheckFlagConstraints(model, action)...)
+			if err := ValidateActionExamples(model, action); err != nil {
+				errs = append(errs, fmt.Errorf("%s %s example: %w", model.Name(), action.Name(), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isFlagValue returns true if the token is not a flag, the negative numbers are values, for example --offset -100
+func isFlagValue(token string) bool {
+	if !strings.HasPrefix(token, "-") {
+		return true
+	}
+	_, err := strconv.ParseFloat(token, 64)
+	return err == nil
+}
+
+func actionFlags(commandSpec spec.ExpModelCommandSpec, action spec.ExpActionCommandSpec) []spec.ExpFlagSpec {
+	flags, _ := spec.EffectiveFlags(commandSpec, action)
+	return flags
+}
+
+// splitCommandLine splits the line by blanks, the quoted words are kept in one token without quotes
+func splitCommandLine(line string) ([]string, error) {
+	tokens := make([]string, 0)
+	var token strings.Builder
+	var quote rune
+	inToken := false
+	for _, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			token.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inToken = r, true
+		case r == ' ' || r == '\t':
+			if inToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				inToken = false
+			}
+		default:
+			token.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("%s: unterminated quote", line)
+	}
+	if inToken {
+		tokens = append(tokens, token.String())
+	}
+	return tokens, nil
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+func newExampleCommandModel(example string) *spec.ExpCommandModel {
+	return &spec.ExpCommandModel{
+		ExpName: "network",
+		ExpActions: []spec.ActionModel{
+			{
+				ActionName:     "delay",
+				ActionAliases:  []string{"d"},
+				ActionMatchers: []spec.ExpFlag{{Name: "interface", Required: true}},
+				ActionFlags:    []spec.ExpFlag{{Name: "time", Required: true}, {Name: "force", NoArgs: true}},
+				ActionExample:  example,
+			},
+		},
+	}
+}
+
+func TestParseExampleCommand(t *testing.T) {
+	commandModel := newExampleCommandModel("")
+	tests := []struct {
+		line    string
+		want    map[string]string
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			line: "blade create network delay --time 3000 --interface eth0",
+			want: map[string]string{"time": "3000", "interface": "eth0"},
+		},
+		{
+			line: `./blade c network d --time=3000 --force --interface 'eth 0' -d`,
+			want: map[string]string{"time": "3000", "force": "true", "interface": "eth 0"},
+		},
+		{
+			line: "blade create network delay --time -100 --interface eth0",
+			want: map[string]string{"time": "-100", "interface": "eth0"},
+		},
+		{
+			line:    "blade create cpu load --cpu-percent 60",
+			wantNil: true,
+		},
+		{
+			line:    "blade create k8s pod-network delay --names network --time 3000",
+			wantNil: true,
+		},
+		{
+			line:    "blade create process kill --process network",
+			wantNil: true,
+		},
+		{
+			line:    "blade create network --time 3000",
+			wantErr: true,
+		},
+		{
+			line:    "blade create network loss --percent 60",
+			wantErr: true,
+		},
+		{
+			line:    "blade create network delay --interface 'eth0",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			model, err := ParseExampleCommand(tt.line, commandModel)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExampleCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (model == nil) != tt.wantNil {
+				t.Fatalf("ParseExampleCommand() = %v, wantNil %v", model, tt.wantNil)
+			}
+			if model != nil && !reflect.DeepEqual(model.ActionFlags, tt.want) {
+				t.Errorf("ParseExampleCommand() flags = %v, want %v", model.ActionFlags, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateModels(t *testing.T) {
+	tests := []struct {
+		name    string
+		example string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			example: `# Access to native 8080 port is delayed by 3 seconds
+blade create network delay --time 3000 --interface eth0 --timeout 60
+
+# Other targets are ignored
+blade create cpu load`,
+		},
+		{
+			name:    "flag not declared",
+			example: "blade create network delay --time 3000 --interface eth0 --offset 10",
+			wantErr: true,
+		},
+		{
+			name:    "required flag missing",
+			example: "blade create network delay --time 3000",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			models := &spec.Models{Models: []spec.ExpCommandModel{*newExampleCommandModel(tt.example)}}
+			if err := ValidateModels(models); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateModels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateModelsUnknownCategory(t *testing.T) {
+	model := newExampleCommandModel("blade create network delay --time 3000 --interface eth0")
+	model.ExpActions[0].ActionCategories = []string{"middleware/unknown"}
+	if err := ValidateModels(&spec.Models{Models: []spec.ExpCommandModel{*model}}); err != nil {
+		t.Errorf("ValidateModels() error = %v, want the unknown category only warned", err)
+	}
+}
+
+func TestCreateYamlFile(t *testing.T) {
+	models := &spec.Models{Models: []spec.ExpCommandModel{*newExampleCommandModel("blade create network delay --time 3000")}}
+	specFile := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := CreateYamlFile(models, specFile); err == nil {
+		t.Errorf("CreateYamlFile() of the stale example returns no error")
+	}
+	if _, err := os.Stat(specFile); !os.IsNotExist(err) {
+		t.Errorf("CreateYamlFile() of the stale example creates the spec file, err = %v", err)
+	}
+	if err := CreateYamlFileWithoutValidation(models, specFile); err != nil {
+		t.Errorf("CreateYamlFileWithoutValidation() error = %v", err)
+	}
+}
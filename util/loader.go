@@ -0,0 +1,315 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+// OverlayKind is the kind of the yaml file patching the specs
+const OverlayKind = "overlay"
+
+// SpecOverlay is the yaml file of overlay kind, the distributions customize the shipped specs by it
+type SpecOverlay struct {
+	Version string      `yaml:"version"`
+	Kind    string      `yaml:"kind"`
+	Patches []SpecPatch `yaml:"items"`
+}
+
+// SpecPatch patches the target, or the action of the target if the action is not empty.
+// The target of all scopes is patched if the scope is empty.
+type SpecPatch struct {
+	Target string `yaml:"target"`
+	Scope  string `yaml:"scope,omitempty"`
+	Action string `yaml:"action,omitempty"`
+
+	// Hidden removes the target or the action
+	Hidden bool `yaml:"hidden,omitempty"`
+
+	ShortDesc string `yaml:"shortDesc,omitempty"`
+	LongDesc  string `yaml:"longDesc,omitempty"`
+	Example   string `yaml:"example,omitempty"`
+
+	// Defaults overrides the default values of the flags by the flag names
+	Defaults map[string]string `yaml:"defaults,omitempty"`
+}
+
+// GetYamlPaths returns the spec directories, YAML_PATH may contain multiple paths separated by the os path list separator
+func GetYamlPaths() []string {
+	paths := make([]string, 0)
+	for _, p := range filepath.SplitList(GetYamlHome()) {
+		if strings.TrimSpace(p) != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// LoadSpecsFromYamlHome loads the specs under the spec directories returned by GetYamlPaths
+func LoadSpecsFromYamlHome(executor spec.Executor) (*spec.Models, error) {
+	return LoadSpecs(executor, GetYamlPaths()...)
+}
+
+// LoadSpecs reads every yaml file under the paths recursively, the path can be a file or a directory.
+// The models are merged in the order of the paths and the file names, it's a conflict if the same target
+// is defined by different files. The overlay files are applied after all models are merged.
+func LoadSpecs(executor spec.Executor, paths ...string) (*spec.Models, error) {
+	loader := newSpecLoader()
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || !isYamlFile(file) {
+				return nil
+			}
+			bytes, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			return loader.add(file, bytes)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return loader.finish(executor)
+}
+
+// LoadSpecsFS is the same as LoadSpecs, but reads the yaml files in the file system, for example the embed.FS.
+// The root is the directory or the file in the file system, the whole file system is loaded if no roots.
+func LoadSpecsFS(fsys fs.FS, executor spec.Executor, roots ...string) (*spec.Models, error) {
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+	loader := newSpecLoader()
+	for _, root := range roots {
+		err := fs.WalkDir(fsys, root, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || !isYamlFile(file) {
+				return nil
+			}
+			bytes, err := fs.ReadFile(fsys, file)
+			if err != nil {
+				return err
+			}
+			return loader.add(file, bytes)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return loader.finish(executor)
+}
+
+// LoadEmbeddedSpecs loads the specs compiled in the binary, and falls back to the spec directories
+// returned by GetYamlPaths if the file system is nil or contains no targets
+func LoadEmbeddedSpecs(fsys fs.FS, executor spec.Executor) (*spec.Models, error) {
+	if fsys != nil {
+		models, err := LoadSpecsFS(fsys, executor)
+		if err != nil {
+			return nil, err
+		}
+		if len(models.Models) > 0 {
+			return models, nil
+		}
+	}
+	return LoadSpecsFromYamlHome(executor)
+}
+
+func isYamlFile(file string) bool {
+	ext := strings.ToLower(path.Ext(file))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// specLoader merges the models and collects the overlays of the spec files
+type specLoader struct {
+	parts    []*spec.Models
+	files    map[string]string
+	loaded   map[string]spec.ExpCommandModel
+	overlays []SpecOverlay
+}
+
+func newSpecLoader() *specLoader {
+	return &specLoader{
+		parts:    make([]*spec.Models, 0),
+		files:    make(map[string]string),
+		loaded:   make(map[string]spec.ExpCommandModel),
+		overlays: make([]SpecOverlay, 0),
+	}
+}
+
+// modelKey identifies the target, the same target is defined once per scope, for example os, docker and k8s
+func modelKey(model *spec.ExpCommandModel) string {
+	return model.ExpScope + "/" + model.ExpName
+}
+
+// modelTitle returns the target name with the scope for the messages
+func modelTitle(model *spec.ExpCommandModel) string {
+	if model.ExpScope == "" {
+		return model.ExpName
+	}
+	return fmt.Sprintf("%s(%s)", model.ExpName, model.ExpScope)
+}
+
+func (l *specLoader) add(file string, bytes []byte) error {
+	var header struct {
+		Kind string `yaml:"kind"`
+	}
+	if err := yaml.Unmarshal(bytes, &header); err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	if header.Kind == OverlayKind {
+		var overlay SpecOverlay
+		if err := yaml.Unmarshal(bytes, &overlay); err != nil {
+			return fmt.Errorf("%s: %v", file, err)
+		}
+		l.overlays = append(l.overlays, overlay)
+		return nil
+	}
+	models := &spec.Models{}
+	if err := yaml.Unmarshal(bytes, models); err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	part := &spec.Models{Version: models.Version, Kind: models.Kind, Models: make([]spec.ExpCommandModel, 0)}
+	for _, model := range models.Models {
+		key := modelKey(&model)
+		previous, ok := l.files[key]
+		if !ok {
+			l.files[key] = file
+			l.loaded[key] = model
+			part.Models = append(part.Models, model)
+			continue
+		}
+		if !reflect.DeepEqual(l.loaded[key], model) {
+			return fmt.Errorf("spec conflict, the %s target is defined in both %s and %s", modelTitle(&model), previous, file)
+		}
+	}
+	l.parts = append(l.parts, part)
+	return nil
+}
+
+func (l *specLoader) finish(executor spec.Executor) (*spec.Models, error) {
+	models := MergeModels(l.parts...)
+	for _, overlay := range l.overlays {
+		if err := ApplyOverlay(models, &overlay); err != nil {
+			return nil, err
+		}
+	}
+	for idx := range models.Models {
+		models.Models[idx].ExpExecutor = executor
+	}
+	return models, nil
+}
+
+// ApplyOverlay patches the models, it returns error if the target, the action or the flag of the patch is not found
+func ApplyOverlay(models *spec.Models, overlay *SpecOverlay) error {
+	for _, patch := range overlay.Patches {
+		kept := make([]spec.ExpCommandModel, 0, len(models.Models))
+		found := false
+		for idx := range models.Models {
+			model := &models.Models[idx]
+			if model.ExpName != patch.Target || (patch.Scope != "" && model.ExpScope != patch.Scope) {
+				kept = append(kept, *model)
+				continue
+			}
+			found = true
+			if err := patchModel(model, &patch); err != nil {
+				return fmt.Errorf("overlay: the %s target, %v", modelTitle(model), err)
+			}
+			if patch.Action != "" || !patch.Hidden {
+				kept = append(kept, *model)
+			}
+		}
+		if !found {
+			return fmt.Errorf("overlay: the %s target not found", modelTitle(&spec.ExpCommandModel{ExpName: patch.Target, ExpScope: patch.Scope}))
+		}
+		models.Models = kept
+	}
+	return nil
+}
+
+// patchModel patches the target or the action, the hidden target is removed by ApplyOverlay
+func patchModel(model *spec.ExpCommandModel, patch *SpecPatch) error {
+	if patch.Action != "" {
+		return patchAction(model, patch)
+	}
+	if patch.Hidden {
+		return nil
+	}
+	patchString(&model.ExpShortDesc, patch.ShortDesc)
+	patchString(&model.ExpLongDesc, patch.LongDesc)
+	return patchDefaults(patch.Defaults, model.ExpFlags)
+}
+
+func patchAction(model *spec.ExpCommandModel, patch *SpecPatch) error {
+	for idx := range model.ExpActions {
+		action := &model.ExpActions[idx]
+		if action.ActionName != patch.Action {
+			continue
+		}
+		if patch.Hidden {
+			model.ExpActions = append(model.ExpActions[:idx], model.ExpActions[idx+1:]...)
+			return nil
+		}
+		patchString(&action.ActionShortDesc, patch.ShortDesc)
+		patchString(&action.ActionLongDesc, patch.LongDesc)
+		patchString(&action.ActionExample, patch.Example)
+		if err := patchDefaults(patch.Defaults, action.ActionMatchers, action.ActionFlags); err != nil {
+			return fmt.Errorf("the %s action, %v", patch.Action, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("the %s action not found", patch.Action)
+}
+
+func patchString(field *string, value string) {
+	if value != "" {
+		*field = value
+	}
+}
+
+// patchDefaults sets the default values to the flags in place
+func patchDefaults(defaults map[string]string, flagGroups ...[]spec.ExpFlag) error {
+	for name, value := range defaults {
+		found := false
+		for _, flags := range flagGroups {
+			for idx := range flags {
+				if flags[idx].Name == name {
+					flags[idx].Default = value
+					found = true
+				}
+			}
+		}
+		if !found {
+			return fmt.Errorf("the %s flag not found", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const networkSpec = `version: v1
+kind: plugin
+items:
+- target: network
+  shortDesc: Network experiment
+  actions:
+  - action: delay
+    shortDesc: delay experiment
+    flags:
+    - name: time
+      required: true
+    - name: offset
+      default: "10"
+  - action: loss
+    shortDesc: loss experiment
+`
+
+const cpuSpec = `version: v1
+kind: plugin
+items:
+- target: cpu
+  shortDesc: Cpu experiment
+  actions:
+  - action: fullload
+`
+
+func writeSpecFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadSpecs(t *testing.T) {
+	dir := writeSpecFiles(t, map[string]string{
+		"os/network.yaml":     networkSpec,
+		"os/cpu.yml":          cpuSpec,
+		"vendor/network.yaml": networkSpec,
+		"README.md":           "not a spec",
+		"overlay.yaml": `kind: overlay
+items:
+- target: network
+  shortDesc: Customized network experiment
+- target: network
+  action: delay
+  defaults:
+    offset: "20"
+- target: network
+  action: loss
+  hidden: true
+`,
+	})
+	models, err := LoadSpecs(nil, dir)
+	if err != nil {
+		t.Fatalf("LoadSpecs() error = %v", err)
+	}
+	if len(models.Models) != 2 || models.Models[0].ExpName != "cpu" || models.Models[1].ExpName != "network" {
+		t.Fatalf("LoadSpecs() = %v, want cpu and network targets", models.Models)
+	}
+	network := models.Models[1]
+	if network.ExpShortDesc != "Customized network experiment" {
+		t.Errorf("shortDesc = %s, want the overlay value", network.ExpShortDesc)
+	}
+	if len(network.ExpActions) != 1 || network.ExpActions[0].ActionFlags[1].Default != "20" {
+		t.Errorf("actions = %v, want the delay action with offset default 20", network.ExpActions)
+	}
+}
+
+func TestLoadSpecsScopes(t *testing.T) {
+	scoped := func(scope string) string {
+		return strings.Replace(cpuSpec, "- target: cpu\n", "- target: cpu\n  scope: "+scope+"\n", 1)
+	}
+	dir := writeSpecFiles(t, map[string]string{
+		"os/cpu.yaml":     strings.Replace(scoped("host"), "version: v1", "version: v2", 1),
+		"docker/cpu.yaml": strings.Replace(scoped("container"), "Cpu experiment", "Container cpu experiment", 1),
+		"k8s/cpu.yaml":    scoped("pod"),
+		"overlay.yaml":    "kind: overlay\nitems:\n- target: cpu\n  scope: container\n  shortDesc: Customized\n- target: cpu\n  scope: pod\n  hidden: true\n",
+	})
+	models, err := LoadSpecs(nil, dir)
+	if err != nil {
+		t.Fatalf("LoadSpecs() error = %v", err)
+	}
+	descs := make(map[string]string)
+	for _, model := range models.Models {
+		descs[model.ExpScope] = model.ExpShortDesc
+	}
+	want := map[string]string{"container": "Customized", "host": "Cpu experiment"}
+	if !reflect.DeepEqual(descs, want) {
+		t.Errorf("LoadSpecs() = %v, want %v", descs, want)
+	}
+	// the version of the last file, os/cpu.yaml, is kept like MergeModels
+	if models.Version != "v2" {
+		t.Errorf("LoadSpecs() version = %s, want v2", models.Version)
+	}
+}
+
+func TestLoadSpecsError(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name: "conflict",
+			files: map[string]string{
+				"a/network.yaml": networkSpec,
+				"b/network.yaml": strings.Replace(networkSpec, "Network experiment", "Other network experiment", 1),
+			},
+			want: "spec conflict",
+		},
+		{
+			name: "overlay target not found",
+			files: map[string]string{
+				"cpu.yaml":     cpuSpec,
+				"overlay.yaml": "kind: overlay\nitems:\n- target: network\n  hidden: true\n",
+			},
+			want: "the network target not found",
+		},
+		{
+			name: "overlay scope not found",
+			files: map[string]string{
+				"cpu.yaml":     cpuSpec,
+				"overlay.yaml": "kind: overlay\nitems:\n- target: cpu\n  scope: pod\n  hidden: true\n",
+			},
+			want: "the cpu(pod) target not found",
+		},
+		{
+			name: "overlay flag not found",
+			files: map[string]string{
+				"cpu.yaml":     cpuSpec,
+				"overlay.yaml": "kind: overlay\nitems:\n- target: cpu\n  action: fullload\n  defaults:\n    cpu-count: \"2\"\n",
+			},
+			want: "the cpu-count flag not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadSpecs(nil, writeSpecFiles(t, tt.files))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadSpecs() error = %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSpecsFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"yaml/network.yaml": {Data: []byte(networkSpec)},
+		"yaml/cpu.yaml":     {Data: []byte(cpuSpec)},
+		"yaml/overlay.yaml": {Data: []byte("kind: overlay\nitems:\n- target: cpu\n  hidden: true\n")},
+	}
+	models, err := LoadSpecsFS(fsys, nil, "yaml")
+	if err != nil {
+		t.Fatalf("LoadSpecsFS() error = %v", err)
+	}
+	if len(models.Models) != 1 || models.Models[0].ExpName != "network" {
+		t.Errorf("LoadSpecsFS() = %v, want network target", models.Models)
+	}
+	models, err = ParseSpecsFromFS(fsys, "yaml/cpu.yaml", nil)
+	if err != nil || len(models.Models) != 1 || models.Models[0].ExpName != "cpu" {
+		t.Errorf("ParseSpecsFromFS() = %v, %v, want cpu target", models, err)
+	}
+}
+
+type failedWriter struct{}
+
+func (failedWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestMarshalModelSpecWriteError(t *testing.T) {
+	models, err := ParseSpecs(strings.NewReader(cpuSpec), nil)
+	if err != nil {
+		t.Fatalf("ParseSpecs() error = %v", err)
+	}
+	if err := MarshalModelSpec(models, failedWriter{}); err == nil {
+		t.Errorf("MarshalModelSpec() error = nil, want the write error")
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+// ResolveProgram returns the path of the program declared by the action under the bin directory,
+// the absolute program path is returned directly. The bin directory is GetBinPath() if it's empty.
+func ResolveProgram(binPath, program string) string {
+	if filepath.IsAbs(program) {
+		return program
+	}
+	if binPath == "" {
+		binPath = GetBinPath()
+	}
+	file := filepath.Join(binPath, program)
+	if runtime.GOOS == "windows" && filepath.Ext(file) == "" && !IsExist(file) {
+		file += ".exe"
+	}
+	return file
+}
+
+// CheckPrograms returns the errors of the programs declared by the actions of the models and their sub models
+// which are not found under the bin directory, or are not executable files.
+// The packaging uses it to verify every referenced program ships.
+func CheckPrograms(binPath string, models *spec.Models) []error {
+	errs := make([]error, 0)
+	for idx := range models.Models {
+		model := &models.Models[idx]
+		errs = append(errs, checkPrograms(binPath, model.Name(), model)...)
+	}
+	return errs
+}
+
+func checkPrograms(binPath, path string, model *spec.ExpCommandModel) []error {
+	errs := make([]error, 0)
+	for _, action := range model.Actions() {
+		for _, program := range action.Programs() {
+			if err := checkProgram(ResolveProgram(binPath, program)); err != nil {
+				errs = append(errs, fmt.Errorf("%s %s: %v", path, action.Name(), err))
+			}
+		}
+	}
+	for idx := range model.ExpSubModels {
+		child := &model.ExpSubModels[idx]
+		errs = append(errs, checkPrograms(binPath, path+" "+child.Name(), child)...)
+	}
+	return errs
+}
+
+func checkProgram(file string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fmt.Errorf("the %s program not found", file)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("the %s program is a directory", file)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o111 == 0 {
+		return fmt.Errorf("the %s program is not executable", file)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+func TestCheckPrograms(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the executable permission is not checked on windows")
+	}
+	binPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binPath, "chaos_os"), []byte("#!/bin/sh"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(binPath, "chaos_readme"), []byte("readme"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cpu := spec.ExpCommandModel{
+		ExpName: "cpu",
+		ExpActions: []spec.ActionModel{
+			{ActionName: "fullload", ActionPrograms: []string{"chaos_os"}},
+			{ActionName: "load", ActionPrograms: []string{"chaos_readme", "chaos_missing"}},
+		},
+	}
+	pod := spec.ExpCommandModel{ExpName: "pod"}
+	pod.AddSubModels(spec.ExpCommandModel{
+		ExpName:    "network",
+		ExpActions: []spec.ActionModel{{ActionName: "delay", ActionPrograms: []string{"chaos_network"}}},
+	})
+	models := &spec.Models{Models: []spec.ExpCommandModel{cpu, pod}}
+	errs := CheckPrograms(binPath, models)
+	if len(errs) != 3 {
+		t.Fatalf("CheckPrograms() = %v, want 3 errors", errs)
+	}
+	if !strings.HasPrefix(errs[2].Error(), "pod network delay:") {
+		t.Errorf("CheckPrograms() error of the sub model = %v, want the target path prefixed", errs[2])
+	}
+}
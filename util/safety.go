@@ -0,0 +1,43 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+// LoadSafetyPolicy reads the safety policy from the yaml file onto spec.DefaultSafetyPolicy,
+// so the rules absent in the file keep the default values. The default policy is returned if the file doesn't exist.
+func LoadSafetyPolicy(file string) (*spec.SafetyPolicy, error) {
+	bytes, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return spec.DefaultSafetyPolicy(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	policy := spec.DefaultSafetyPolicy()
+	if err := yaml.Unmarshal(bytes, policy); err != nil {
+		return nil, fmt.Errorf("parse the safety policy %s failed, %v", file, err)
+	}
+	return policy, nil
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+func TestLoadSafetyPolicy(t *testing.T) {
+	dir := t.TempDir()
+	policy, err := LoadSafetyPolicy(filepath.Join(dir, "safety.yaml"))
+	if err != nil || !reflect.DeepEqual(policy, spec.DefaultSafetyPolicy()) {
+		t.Errorf("LoadSafetyPolicy() = %v, %v, want the default policy", policy, err)
+	}
+	file := filepath.Join(dir, "policy.yaml")
+	content := "maxProcesses: 10\nprotectedPaths: [/, /etc]\ndeniedFlagValues:\n  process: [sshd]\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	policy, err = LoadSafetyPolicy(file)
+	want := spec.DefaultSafetyPolicy()
+	want.MaxProcesses = 10
+	want.ProtectedPaths = []string{"/", "/etc"}
+	want.DeniedFlagValues = map[string][]string{"process": {"sshd"}}
+	if err != nil || !reflect.DeepEqual(policy, want) {
+		t.Errorf("LoadSafetyPolicy() = %+v, %v, want %+v", policy, err, want)
+	}
+
+	// the partial file keeps the default protections
+	if err := os.WriteFile(file, []byte("maxProcesses: 5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	policy, err = LoadSafetyPolicy(file)
+	want = spec.DefaultSafetyPolicy()
+	want.MaxProcesses = 5
+	if err != nil || !reflect.DeepEqual(policy, want) {
+		t.Errorf("LoadSafetyPolicy() = %+v, %v, want %+v", policy, err, want)
+	}
+}
@@ -17,17 +17,29 @@
 package util
 
 import (
+	"fmt"
 	"io"
-	"io/ioutil"
+	"io/fs"
 	"os"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 
 	"github.com/chaosblade-io/chaosblade-spec-go/spec"
 )
 
-// CreateYamlFile converts the spec.Models to spec file
+// CreateYamlFile converts the spec.Models to spec file after ValidateModels passes,
+// so the models with stale examples or invalid constraints are rejected
 func CreateYamlFile(models *spec.Models, specFile string) error {
+	if err := ValidateModels(models); err != nil {
+		return err
+	}
+	return CreateYamlFileWithoutValidation(models, specFile)
+}
+
+// CreateYamlFileWithoutValidation converts the spec.Models to spec file without ValidateModels,
+// it's used to generate the spec file of the models not conforming to ValidateModels yet
+func CreateYamlFileWithoutValidation(models *spec.Models, specFile string) error {
 	file, err := os.OpenFile(specFile, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o755)
 	if err != nil {
 		return err
@@ -42,13 +54,33 @@ func MarshalModelSpec(models *spec.Models, writer io.Writer) error {
 	if err != nil {
 		return err
 	}
-	writer.Write(bytes)
-	return nil
+	_, err = writer.Write(bytes)
+	return err
 }
 
 // ParseSpecsToModel parses the yaml file to spec.Models and set the executor to the spec.Models
 func ParseSpecsToModel(file string, executor spec.Executor) (*spec.Models, error) {
-	bytes, err := ioutil.ReadFile(file)
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseSpecs(f, executor)
+}
+
+// ParseSpecsFromFS parses the yaml file in the file system, for example the embed.FS
+func ParseSpecsFromFS(fsys fs.FS, file string, executor spec.Executor) (*spec.Models, error) {
+	f, err := fsys.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseSpecs(f, executor)
+}
+
+// ParseSpecs parses the yaml content of the reader to spec.Models and set the executor to the spec.Models
+func ParseSpecs(reader io.Reader, executor spec.Executor) (*spec.Models, error) {
+	bytes, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +95,9 @@ func ParseSpecsToModel(file string, executor spec.Executor) (*spec.Models, error
 	return models, nil
 }
 
-// ConvertSpecToModels converts the spec.ExpModelCommandSpec to spec.Models
+// ConvertSpecToModels converts the spec.ExpModelCommandSpec to spec.Models.
+// The target flags are merged into the action flags by the merge policies of the action flags,
+// use spec.FlagConflicts to check the conflicts before converting.
 func ConvertSpecToModels(commandSpec spec.ExpModelCommandSpec, prepare spec.ExpPrepareModel, scope string) *spec.Models {
 	models := &spec.Models{
 		Version: "v1",
@@ -79,6 +113,20 @@ func ConvertSpecToModels(commandSpec spec.ExpModelCommandSpec, prepare spec.ExpP
 		ExpSubTargets:   make([]string, 0),
 		ExpPrepareModel: prepare,
 		ExpScope:        scope,
+		ExpDeprecated:   spec.DeprecationOf(commandSpec),
+	}
+	targetFlags := make(map[string]spec.ExpFlagSpec)
+	for _, flag := range commandSpec.Flags() {
+		if _, ok := targetFlags[flag.FlagName()]; !ok {
+			targetFlags[flag.FlagName()] = flag
+		}
+	}
+	// inherited returns the target flag if the flag inherits it
+	inherited := func(flag spec.ExpFlagSpec) spec.ExpFlagSpec {
+		if targetFlag, ok := targetFlags[flag.FlagName()]; ok && spec.FlagMerge(flag) == spec.FlagInherit {
+			return targetFlag
+		}
+		return flag
 	}
 	for _, action := range commandSpec.Actions() {
 		actionModel := spec.ActionModel{
@@ -90,13 +138,7 @@ func ConvertSpecToModels(commandSpec spec.ExpModelCommandSpec, prepare spec.ExpP
 			ActionMatchers: func() []spec.ExpFlag {
 				matchers := make([]spec.ExpFlag, 0)
 				for _, m := range action.Matchers() {
-					matchers = append(matchers, spec.ExpFlag{
-						Name:                  m.FlagName(),
-						Desc:                  m.FlagDesc(),
-						NoArgs:                m.FlagNoArgs(),
-						Required:              m.FlagRequired(),
-						RequiredWhenDestroyed: m.FlagRequiredWhenDestroyed(),
-					})
+					matchers = append(matchers, convertFlag(inherited(m)))
 				}
 				return matchers
 			}(),
@@ -107,59 +149,54 @@ func ConvertSpecToModels(commandSpec spec.ExpModelCommandSpec, prepare spec.ExpP
 					if _, ok := flagsMap[m.FlagName()]; ok {
 						continue
 					}
-					flags = append(flags, spec.ExpFlag{
-						Name:                  m.FlagName(),
-						Desc:                  m.FlagDesc(),
-						NoArgs:                m.FlagNoArgs(),
-						Required:              m.FlagRequired(),
-						RequiredWhenDestroyed: m.FlagRequiredWhenDestroyed(),
-					})
 					flagsMap[m.FlagName()] = struct{}{}
+					// the hidden target flag and the hiding action flag are both removed
+					if spec.FlagMerge(m) == spec.FlagHide {
+						continue
+					}
+					flags = append(flags, convertFlag(inherited(m)))
 				}
 				for _, m := range commandSpec.Flags() {
 					if _, ok := flagsMap[m.FlagName()]; ok {
 						continue
 					}
-					flags = append(flags, spec.ExpFlag{
-						Name:                  m.FlagName(),
-						Desc:                  m.FlagDesc(),
-						NoArgs:                m.FlagNoArgs(),
-						Required:              m.FlagRequired(),
-						RequiredWhenDestroyed: m.FlagRequiredWhenDestroyed(),
-					})
+					flags = append(flags, convertFlag(m))
 					flagsMap[m.FlagName()] = struct{}{}
 				}
-				if _, ok := flagsMap["timeout"]; !ok {
+				if _, ok := flagsMap[spec.TimeoutFlag]; !ok {
 					flags = append(flags, spec.ExpFlag{
-						Name:                  "timeout",
+						Name:                  spec.TimeoutFlag,
 						Desc:                  "set timeout for experiment",
 						Required:              false,
 						RequiredWhenDestroyed: false,
 					})
-					flagsMap["timeout"] = struct{}{}
+					flagsMap[spec.TimeoutFlag] = struct{}{}
 				}
-				if _, ok := flagsMap["async"]; !ok {
+				if _, ok := flagsMap[spec.AsyncFlag]; !ok {
 					flags = append(flags, spec.ExpFlag{
-						Name:     "async",
+						Name:     spec.AsyncFlag,
 						Desc:     "whether to create asynchronously, default is false",
 						Required: false,
 						NoArgs:   true,
 					})
-					flagsMap["async"] = struct{}{}
+					flagsMap[spec.AsyncFlag] = struct{}{}
 				}
-				if _, ok := flagsMap["endpoint"]; !ok {
+				if _, ok := flagsMap[spec.EndpointFlag]; !ok {
 					flags = append(flags, spec.ExpFlag{
-						Name:     "endpoint",
+						Name:     spec.EndpointFlag,
 						Desc:     "the create result reporting address. It takes effect only when the async value is true and the value is not empty",
 						Required: false,
 					})
-					flagsMap["endpoint"] = struct{}{}
+					flagsMap[spec.EndpointFlag] = struct{}{}
 				}
 				return flags
 			}(),
-			ActionPrograms:    action.Programs(),
-			ActionCategories:  action.Categories(),
-			ActionProcessHang: action.ProcessHang(),
+			ActionPrograms:     action.Programs(),
+			ActionCategories:   action.Categories(),
+			ActionProcessHang:  action.ProcessHang(),
+			ActionConstraints:  spec.ActionConstraints(action),
+			ActionDeprecated:   spec.DeprecationOf(action),
+			ActionRequirements: spec.RequirementsOf(action),
 		}
 		model.ExpActions = append(model.ExpActions, actionModel)
 	}
@@ -167,7 +204,30 @@ func ConvertSpecToModels(commandSpec spec.ExpModelCommandSpec, prepare spec.ExpP
 	return models
 }
 
+// convertFlag converts the flag spec to the flag model of the yaml file
+func convertFlag(flag spec.ExpFlagSpec) spec.ExpFlag {
+	return spec.ExpFlag{
+		Name:                  flag.FlagName(),
+		Desc:                  flag.FlagDesc(),
+		NoArgs:                flag.FlagNoArgs(),
+		Required:              flag.FlagRequired(),
+		RequiredWhenDestroyed: flag.FlagRequiredWhenDestroyed(),
+		Deprecated:            spec.DeprecationOf(flag),
+		Merge:                 declaredMerge(flag),
+	}
+}
+
+// declaredMerge returns the merge policy declared by the flag, it's empty if the flag doesn't declare it
+func declaredMerge(flag spec.ExpFlagSpec) spec.FlagMergePolicy {
+	if mergeSpec, ok := flag.(spec.ExpFlagMergeSpec); ok {
+		return mergeSpec.FlagMerge()
+	}
+	return ""
+}
+
 // AddModels adds the child model to parent
+//
+// Deprecated: AddModels appends the child names to every parent model, use AddSubModels instead.
 func AddModels(parent *spec.Models, child *spec.Models) {
 	for idx, model := range parent.Models {
 		for _, sub := range child.Models {
@@ -177,6 +237,26 @@ func AddModels(parent *spec.Models, child *spec.Models) {
 	}
 }
 
+// AddSubModels attaches the child models to the parent target of the path, for example "k8s pod"
+func AddSubModels(parent *spec.Models, targetPath string, child *spec.Models) error {
+	words := strings.Fields(targetPath)
+	if len(words) == 0 {
+		return fmt.Errorf("the target path is empty")
+	}
+	model := parent.FindModel(words[0])
+	for _, word := range words[1:] {
+		if model == nil {
+			break
+		}
+		model = model.FindSubModel(word)
+	}
+	if model == nil {
+		return fmt.Errorf("the %s target not found", targetPath)
+	}
+	model.AddSubModels(child.Models...)
+	return nil
+}
+
 // MergeModels
 func MergeModels(models ...*spec.Models) *spec.Models {
 	result := &spec.Models{
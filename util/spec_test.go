@@ -0,0 +1,83 @@
+/*
+ * Copyright 2025 The ChaosBlade Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chaosblade-io/chaosblade-spec-go/spec"
+)
+
+func TestConvertSpecToModels(t *testing.T) {
+	commandSpec := &spec.ExpCommandModel{
+		ExpName:  "process",
+		ExpFlags: []spec.ExpFlag{{Name: "process"}, {Name: "signal"}, {Name: "user"}},
+		ExpActions: []spec.ActionModel{{
+			ActionName:     "kill",
+			ActionMatchers: []spec.ExpFlag{{Name: "process", Required: true}, {Name: "pid"}},
+			ActionFlags: []spec.ExpFlag{
+				{Name: "pid", Desc: "action pid"},
+				{Name: "signal", Required: true, Merge: spec.FlagOverride},
+				{Name: "user", Merge: spec.FlagHide},
+			},
+		}},
+	}
+	models := ConvertSpecToModels(commandSpec, spec.ExpPrepareModel{}, "host")
+	action := models.Models[0].ExpActions[0]
+
+	names := make([]string, 0)
+	for _, flag := range action.ActionFlags {
+		names = append(names, flag.Name)
+	}
+	// the flags sharing the names with the matchers are kept
+	want := []string{"pid", "signal", "process", spec.TimeoutFlag, spec.AsyncFlag, spec.EndpointFlag}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("ConvertSpecToModels() flags = %v, want %v", names, want)
+	}
+	if action.ActionFlags[1].Merge != spec.FlagOverride || action.ActionFlags[0].Merge != "" {
+		t.Errorf("ConvertSpecToModels() merge = %q, %q, want override and empty", action.ActionFlags[1].Merge, action.ActionFlags[0].Merge)
+	}
+
+	if action.ActionMatchers[0].Required || !action.ActionFlags[1].Required {
+		t.Errorf("ConvertSpecToModels() process required = %t, signal required = %t, want the inherited process and the overriding signal",
+			action.ActionMatchers[0].Required, action.ActionFlags[1].Required)
+	}
+
+	var yaml strings.Builder
+	if err := MarshalModelSpec(models, &yaml); err != nil {
+		t.Fatalf("MarshalModelSpec() error = %v", err)
+	}
+	if !strings.Contains(yaml.String(), "merge: override") {
+		t.Errorf("MarshalModelSpec() does not contain the merge policy:\n%s", yaml.String())
+	}
+}
+
+func TestValidateModelsFlagConflicts(t *testing.T) {
+	models := &spec.Models{Models: []spec.ExpCommandModel{{
+		ExpName:  "process",
+		ExpFlags: []spec.ExpFlag{{Name: "signal", Required: true}},
+		ExpActions: []spec.ActionModel{{
+			ActionName:  "kill",
+			ActionFlags: []spec.ExpFlag{{Name: "signal"}},
+		}},
+	}}}
+	if err := ValidateModels(models); err == nil || !strings.Contains(err.Error(), "flag conflict") {
+		t.Errorf("ValidateModels() error = %v, want the flag conflict", err)
+	}
+}
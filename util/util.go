@@ -33,6 +33,8 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -194,6 +196,15 @@ func Curl(ctx context.Context, url string) (string, error, int) {
 
 // PostCurl
 func PostCurl(url string, body []byte, contentType string) (string, error, int) {
+	headers := make(map[string]string)
+	if contentType != "" {
+		headers["Content-Type"] = contentType
+	}
+	return PostCurlWithHeaders(url, body, headers)
+}
+
+// PostCurlWithHeaders posts the body to url with the request headers
+func PostCurlWithHeaders(url string, body []byte, headers map[string]string) (string, error, int) {
 	trans := http.Transport{
 		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
 			return net.DialTimeout(network, addr, 10*time.Second)
@@ -206,8 +217,8 @@ func PostCurl(url string, body []byte, contentType string) (string, error, int)
 	if err != nil {
 		return "", err, 0
 	}
-	if contentType != "" {
-		req.Header.Set("Content-Type", contentType)
+	for key, value := range headers {
+		req.Header.Set(key, value)
 	}
 	response, err := client.Do(req)
 	if err != nil {
@@ -259,3 +270,45 @@ func GetRunFuncName() string {
 	f := runtime.FuncForPC(pc[0])
 	return f.Name()
 }
+
+// CompareVersion compares the numeric parts of the versions, the suffix after the numbers is ignored,
+// for example 5.10.0-136.el8 is greater than 4.9. It returns -1, 0 or 1.
+func CompareVersion(version, other string) int {
+	parts, otherParts := versionParts(version), versionParts(other)
+	for idx := 0; idx < len(parts) || idx < len(otherParts); idx++ {
+		var part, otherPart int
+		if idx < len(parts) {
+			part = parts[idx]
+		}
+		if idx < len(otherParts) {
+			otherPart = otherParts[idx]
+		}
+		if part != otherPart {
+			if part < otherPart {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) []int {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	parts := make([]int, 0)
+	for _, field := range strings.Split(version, ".") {
+		end := 0
+		for end < len(field) && field[end] >= '0' && field[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		number, _ := strconv.Atoi(field[:end])
+		parts = append(parts, number)
+		if end < len(field) {
+			break
+		}
+	}
+	return parts
+}
@@ -41,3 +41,22 @@ func TestIsExist_ForMountPoint(t *testing.T) {
 		}
 	}
 }
+
+func TestCompareVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		other   string
+		want    int
+	}{
+		{version: "5.10.0-136.el8.x86_64", other: "4.9", want: 1},
+		{version: "4.9", other: "4.9.0", want: 0},
+		{version: "4.9.12", other: "4.19", want: -1},
+		{version: "3.10.0-1160.el7.x86_64", other: "3.10", want: 0},
+		{version: "v1.2", other: "1.10", want: -1},
+	}
+	for _, tt := range tests {
+		if got := CompareVersion(tt.version, tt.other); got != tt.want {
+			t.Errorf("CompareVersion(%s, %s) = %d, want %d", tt.version, tt.other, got, tt.want)
+		}
+	}
+}